@@ -4,13 +4,23 @@ Copyright (c) 2023 Infisical Inc.
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"crypto"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"os/exec"
+	"os/signal"
 	"path/filepath"
+	"sort"
+	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/Infisical/infisical-merge/packages/api"
@@ -19,9 +29,11 @@ import (
 	infisicalSdk "github.com/infisical/go-sdk"
 	infisicalSdkUtil "github.com/infisical/go-sdk/packages/util"
 	"github.com/manifoldco/promptui"
+	"github.com/pkg/sftp"
 	"github.com/spf13/cobra"
 	"golang.org/x/crypto/ssh"
 	"golang.org/x/crypto/ssh/agent"
+	"golang.org/x/term"
 )
 
 var sshCmd = &cobra.Command{
@@ -62,6 +74,228 @@ var sshAddHostCmd = &cobra.Command{
 	Run:   sshAddHost,
 }
 
+// hostKeyCertPair ties together the three files sshd needs per host key
+// algorithm: the existing public/private key and the certificate issued for
+// it, so add-host can manage several algorithms (ed25519, ecdsa, rsa) at once.
+type hostKeyCertPair struct {
+	KeyType        string
+	PubKeyPath     string
+	CertPath       string
+	PrivateKeyPath string
+}
+
+// CredentialMaterial bundles everything a sign-key, issue-credentials, or
+// add-host call can produce, so a single CredentialSink can describe how to
+// dispose of it without caring whether that's a file on disk, stdout, an
+// ssh-agent, or an in-memory store for embedders.
+type CredentialMaterial struct {
+	PrivateKey  string
+	PublicKey   string
+	Certificate string
+	CaPublicKey string
+	Principals  []string
+	ValidAfter  time.Time
+	ValidBefore time.Time
+}
+
+// CredentialSink disposes of issued SSH credential material. Selected via
+// --output=file|json|agent|memory.
+type CredentialSink interface {
+	Emit(material CredentialMaterial) error
+}
+
+// fileCredentialSink writes private key, public key, and certificate to the
+// paths supplied at construction time. This is the CLI's original, and still
+// default, behavior.
+type fileCredentialSink struct {
+	PrivateKeyPath string
+	PublicKeyPath  string
+	CertPath       string
+}
+
+func (s fileCredentialSink) Emit(material CredentialMaterial) error {
+	if s.PrivateKeyPath != "" && material.PrivateKey != "" {
+		if err := writeToFile(s.PrivateKeyPath, material.PrivateKey, 0600); err != nil {
+			return fmt.Errorf("failed to write private key: %w", err)
+		}
+	}
+	if s.PublicKeyPath != "" && material.PublicKey != "" {
+		if err := writeToFile(s.PublicKeyPath, material.PublicKey, 0644); err != nil {
+			return fmt.Errorf("failed to write public key: %w", err)
+		}
+	}
+	if s.CertPath != "" && material.Certificate != "" {
+		if err := writeToFile(s.CertPath, material.Certificate, 0644); err != nil {
+			return fmt.Errorf("failed to write certificate: %w", err)
+		}
+	}
+	return nil
+}
+
+// jsonCredentialSink prints material to stdout as a single JSON object, so
+// downstream tooling (Ansible, config management) can consume it without
+// shelling out and parsing files.
+type jsonCredentialSink struct{}
+
+func (jsonCredentialSink) Emit(material CredentialMaterial) error {
+	payload := struct {
+		PrivateKey  string   `json:"privateKey,omitempty"`
+		PublicKey   string   `json:"publicKey,omitempty"`
+		Certificate string   `json:"certificate,omitempty"`
+		CaPublicKey string   `json:"caPublicKey,omitempty"`
+		Principals  []string `json:"principals,omitempty"`
+		ValidAfter  string   `json:"validAfter,omitempty"`
+		ValidBefore string   `json:"validBefore,omitempty"`
+	}{
+		PrivateKey:  material.PrivateKey,
+		PublicKey:   material.PublicKey,
+		Certificate: material.Certificate,
+		CaPublicKey: material.CaPublicKey,
+		Principals:  material.Principals,
+	}
+	if !material.ValidAfter.IsZero() {
+		payload.ValidAfter = material.ValidAfter.Format(time.RFC3339)
+	}
+	if !material.ValidBefore.IsZero() {
+		payload.ValidBefore = material.ValidBefore.Format(time.RFC3339)
+	}
+
+	encoded, err := json.MarshalIndent(payload, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal credential material: %w", err)
+	}
+	fmt.Println(string(encoded))
+	return nil
+}
+
+// agentCredentialSink loads the private key and certificate into whatever
+// agent is listening on SSH_AUTH_SOCK, the same way --addToAgent already
+// does.
+type agentCredentialSink struct{}
+
+func (agentCredentialSink) Emit(material CredentialMaterial) error {
+	if material.PrivateKey == "" || material.Certificate == "" {
+		return fmt.Errorf("--output=agent requires both a private key and a certificate")
+	}
+	if err := addCredentialsToAgent(material.PrivateKey, material.Certificate); err != nil {
+		return err
+	}
+	fmt.Println("The SSH key and certificate have been successfully added to your ssh-agent.")
+	return nil
+}
+
+// MemoryCredentialSink stores the most recently emitted material instead of
+// writing it anywhere, for callers embedding this CLI as a library and
+// reading the result back directly rather than through stdout or the
+// filesystem.
+type MemoryCredentialSink struct {
+	Material CredentialMaterial
+}
+
+func NewMemoryCredentialSink() *MemoryCredentialSink {
+	return &MemoryCredentialSink{}
+}
+
+func (s *MemoryCredentialSink) Emit(material CredentialMaterial) error {
+	s.Material = material
+	return nil
+}
+
+// newCredentialSink builds the CredentialSink selected by --output. "file"
+// (the default) reuses filePaths, which callers populate from their existing
+// --outFilePath-style flags.
+func newCredentialSink(output string, filePaths fileCredentialSink) (CredentialSink, error) {
+	switch output {
+	case "", "file":
+		return filePaths, nil
+	case "json":
+		return jsonCredentialSink{}, nil
+	case "agent":
+		return agentCredentialSink{}, nil
+	case "memory":
+		return NewMemoryCredentialSink(), nil
+	default:
+		return nil, fmt.Errorf("invalid --output: %s (must be file, json, agent, or memory)", output)
+	}
+}
+
+// parseCertValidity extracts a certificate's validity window without
+// requiring its private key, unlike parseCertAndKey.
+func parseCertValidity(certContent string) (validAfter, validBefore time.Time, err error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certContent))
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	cert, ok := publicKey.(*ssh.Certificate)
+	if !ok {
+		return time.Time{}, time.Time{}, fmt.Errorf("parsed key is not a certificate")
+	}
+
+	validAfter = time.Unix(int64(cert.ValidAfter), 0)
+	if cert.ValidBefore != ssh.CertTimeInfinity {
+		validBefore = time.Unix(int64(cert.ValidBefore), 0)
+	}
+	return validAfter, validBefore, nil
+}
+
+var sshRollbackHostCmd = &cobra.Command{
+	Use:   "rollback-host",
+	Short: "Restore the most recent backup of the Infisical sshd_config drop-in",
+	Run:   sshRollbackHost,
+}
+
+var sshRenewHostCmd = &cobra.Command{
+	Use:   "renew-host",
+	Short: "Periodically renew a registered SSH host's certificate before it expires",
+	Run:   sshRenewHost,
+}
+
+var sshRemoveHostCmd = &cobra.Command{
+	Use:   "remove-host",
+	Short: "Undo local sshd_config changes made by add-host",
+	Run:   sshRemoveHost,
+}
+
+var sshSftpCmd = &cobra.Command{
+	Use:   "sftp",
+	Short: "Transfer a file to or from an SSH host using an issued certificate",
+	Run:   sshSftp,
+}
+
+var sshScpCmd = &cobra.Command{
+	Use:   "scp <source> <destination>",
+	Short: "Copy a file to or from an SSH host using an issued certificate. Prefix the remote side with \"remote:\"",
+	Args:  cobra.ExactArgs(2),
+	Run:   sshScp,
+}
+
+var sshForwardCmd = &cobra.Command{
+	Use:   "forward",
+	Short: "Forward a local or remote port through an SSH host using an issued certificate",
+	Run:   sshForward,
+}
+
+var sshLoadIdentityCmd = &cobra.Command{
+	Use:   "load-identity",
+	Short: "Load a self-contained identity file (from `issue-credentials --identityFile`) into the SSH agent",
+	Run:   sshLoadIdentity,
+}
+
+var sshTrustCmd = &cobra.Command{
+	Use:   "trust",
+	Short: "Install or remove a registered host's Host CA as a trust anchor in a known_hosts file",
+	Run:   sshTrust,
+}
+
+var sshAgentCmd = &cobra.Command{
+	Use:     "agent",
+	Aliases: []string{"renew"},
+	Short:   "Run a long-lived process that keeps an issued SSH user certificate renewed",
+	Long:    "Run a long-lived process that issues an SSH user certificate and re-issues it before it expires, keeping the ssh-agent (or its own in-memory agent) populated with a valid, short-lived credential.",
+	Run:     sshRunAgent,
+}
+
 var algoToFileName = map[infisicalSdkUtil.CertKeyAlgorithm]string{
 	infisicalSdkUtil.RSA2048:   "id_rsa_2048",
 	infisicalSdkUtil.RSA4096:   "id_rsa_4096",
@@ -99,23 +333,27 @@ func writeToFile(filePath string, content string, perm os.FileMode) error {
 	return nil
 }
 
-func addCredentialsToAgent(privateKeyContent, certContent string) error {
-	// Parse the private key
+// parseCertAndKey parses a raw private key and its signed certificate and
+// derives the agent.AddedKey lifetime (in seconds) from the certificate's
+// ValidBefore field. It is shared by every code path that loads issued SSH
+// credentials into either the user's real ssh-agent or an ephemeral
+// in-memory one.
+func parseCertAndKey(privateKeyContent, certContent string) (crypto.PrivateKey, *ssh.Certificate, uint32, error) {
 	privateKey, err := ssh.ParseRawPrivateKey([]byte(privateKeyContent))
 	if err != nil {
-		return fmt.Errorf("failed to parse private key: %w", err)
+		return nil, nil, 0, fmt.Errorf("failed to parse private key: %w", err)
 	}
 
-	// Parse the certificate
 	pubKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(certContent))
 	if err != nil {
-		return fmt.Errorf("failed to parse certificate: %w", err)
+		return nil, nil, 0, fmt.Errorf("failed to parse certificate: %w", err)
 	}
 
 	cert, ok := pubKey.(*ssh.Certificate)
 	if !ok {
-		return fmt.Errorf("parsed key is not a certificate")
+		return nil, nil, 0, fmt.Errorf("parsed key is not a certificate")
 	}
+
 	// Calculate LifetimeSecs based on certificate's valid-to time
 	validUntil := time.Unix(int64(cert.ValidBefore), 0)
 	now := time.Now()
@@ -130,11 +368,17 @@ func addCredentialsToAgent(privateKeyContent, certContent string) error {
 	// Calculate the duration until expiration
 	lifetime := validUntil.Sub(now)
 	if lifetime <= 0 {
-		return fmt.Errorf("certificate is already expired")
+		return nil, nil, 0, fmt.Errorf("certificate is already expired")
 	}
 
-	// Convert duration to seconds
-	lifetimeSecs := uint32(lifetime.Seconds())
+	return privateKey, cert, uint32(lifetime.Seconds()), nil
+}
+
+func addCredentialsToAgent(privateKeyContent, certContent string) error {
+	privateKey, cert, lifetimeSecs, err := parseCertAndKey(privateKeyContent, certContent)
+	if err != nil {
+		return err
+	}
 
 	// Connect to the SSH agent
 	socket := os.Getenv("SSH_AUTH_SOCK")
@@ -164,6 +408,165 @@ func addCredentialsToAgent(privateKeyContent, certContent string) error {
 	return nil
 }
 
+// connectToLocalAgent dials the ssh-agent listening on SSH_AUTH_SOCK and
+// returns a client for it, the same way addCredentialsToAgent does, for
+// callers that need to use the agent's signers directly (e.g. native dialing)
+// rather than just adding a key to it.
+func connectToLocalAgent() (agent.Agent, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, fmt.Errorf("SSH_AUTH_SOCK not set")
+	}
+
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SSH agent: %w", err)
+	}
+
+	return agent.NewClient(conn), nil
+}
+
+// addCredentialsToKeyring loads a signed certificate and its private key into
+// the given agent.Agent. Most callers pass an in-memory one (e.g. created via
+// agent.NewKeyring()) for short-lived credentials, such as a bastion hop
+// certificate, that are kept out of the user's long-running agent; `ssh agent`
+// without --socket passes a client for the agent at SSH_AUTH_SOCK.
+func addCredentialsToKeyring(keyring agent.Agent, privateKeyContent, certContent string) error {
+	privateKey, cert, lifetimeSecs, err := parseCertAndKey(privateKeyContent, certContent)
+	if err != nil {
+		return err
+	}
+
+	err = keyring.Add(agent.AddedKey{
+		PrivateKey:   privateKey,
+		Certificate:  cert,
+		Comment:      "Added via Infisical CLI",
+		LifetimeSecs: lifetimeSecs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add key to in-memory agent: %w", err)
+	}
+
+	return nil
+}
+
+// Identity file PEM block types. An identity file bundles everything needed
+// to use an already-issued SSH certificate on another machine (or a later
+// pipeline step) into a single artifact, the same way Teleport's `tsh`
+// identity files do.
+const (
+	identityBlockPrivateKey = "INFISICAL SSH PRIVATE KEY"
+	identityBlockCert       = "INFISICAL SSH CERTIFICATE"
+	identityBlockCa         = "INFISICAL SSH CA"
+	identityBlockMetadata   = "INFISICAL SSH METADATA"
+)
+
+// identityMetadata carries the non-key-material context useful to a consumer
+// of an identity file: who it's for, where it's meant to be used, and when
+// it stops being valid.
+type identityMetadata struct {
+	Principals  []string `json:"principals,omitempty"`
+	Hostnames   []string `json:"hostnames,omitempty"`
+	CertType    string   `json:"certType,omitempty"`
+	TTL         string   `json:"ttl,omitempty"`
+	ValidBefore int64    `json:"validBefore,omitempty"`
+}
+
+// writeIdentityFile serializes the private key, signed certificate, optional
+// CA public key, and metadata into a single PEM-wrapped file at path.
+func writeIdentityFile(path, privateKey, signedCert, caPublicKey string, meta identityMetadata) error {
+	var buf bytes.Buffer
+
+	if err := pem.Encode(&buf, &pem.Block{Type: identityBlockPrivateKey, Bytes: []byte(privateKey)}); err != nil {
+		return fmt.Errorf("failed to encode private key: %w", err)
+	}
+	if err := pem.Encode(&buf, &pem.Block{Type: identityBlockCert, Bytes: []byte(signedCert)}); err != nil {
+		return fmt.Errorf("failed to encode certificate: %w", err)
+	}
+	if caPublicKey != "" {
+		if err := pem.Encode(&buf, &pem.Block{Type: identityBlockCa, Bytes: []byte(caPublicKey)}); err != nil {
+			return fmt.Errorf("failed to encode CA public key: %w", err)
+		}
+	}
+
+	metaBytes, err := json.Marshal(meta)
+	if err != nil {
+		return fmt.Errorf("failed to encode identity metadata: %w", err)
+	}
+	if err := pem.Encode(&buf, &pem.Block{Type: identityBlockMetadata, Bytes: metaBytes}); err != nil {
+		return fmt.Errorf("failed to encode identity metadata: %w", err)
+	}
+
+	// The file contains an unencrypted private key, so it gets the same
+	// permissions as any other private key file written by this CLI.
+	return writeToFile(path, buf.String(), 0600)
+}
+
+// readIdentityFile parses an identity file previously written by
+// writeIdentityFile back into its component parts.
+func readIdentityFile(path string) (privateKey, signedCert, caPublicKey string, meta identityMetadata, err error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", "", identityMetadata{}, fmt.Errorf("failed to read identity file: %w", err)
+	}
+
+	rest := content
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+
+		switch block.Type {
+		case identityBlockPrivateKey:
+			privateKey = string(block.Bytes)
+		case identityBlockCert:
+			signedCert = string(block.Bytes)
+		case identityBlockCa:
+			caPublicKey = string(block.Bytes)
+		case identityBlockMetadata:
+			if err := json.Unmarshal(block.Bytes, &meta); err != nil {
+				return "", "", "", identityMetadata{}, fmt.Errorf("failed to parse identity metadata: %w", err)
+			}
+		}
+	}
+
+	if privateKey == "" || signedCert == "" {
+		return "", "", "", identityMetadata{}, fmt.Errorf("identity file %s is missing a private key or certificate block", path)
+	}
+
+	return privateKey, signedCert, caPublicKey, meta, nil
+}
+
+func sshLoadIdentity(cmd *cobra.Command, args []string) {
+	filePath, err := cmd.Flags().GetString("file")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --file flag")
+	}
+	if filePath == "" {
+		util.PrintErrorMessageAndExit("You must provide --file")
+	}
+	if strings.HasPrefix(filePath, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			util.HandleError(err, "Failed to resolve home directory")
+		}
+		filePath = strings.Replace(filePath, "~", homeDir, 1)
+	}
+
+	privateKey, signedCert, _, _, err := readIdentityFile(filePath)
+	if err != nil {
+		util.HandleError(err, "Failed to read identity file")
+	}
+
+	if err := addCredentialsToAgent(privateKey, signedCert); err != nil {
+		util.HandleError(err, "Failed to add identity to SSH agent")
+	}
+
+	fmt.Println("✔ Loaded identity file into the SSH agent:", filePath)
+}
+
 func issueCredentials(cmd *cobra.Command, args []string) {
 
 	token, err := util.GetInfisicalToken(cmd)
@@ -254,8 +657,23 @@ func issueCredentials(cmd *cobra.Command, args []string) {
 		util.HandleError(err, "Unable to parse addToAgent flag")
 	}
 
-	if outFilePath == "" && !addToAgent {
-		util.PrintErrorMessageAndExit("You must provide either --outFilePath or --addToAgent flag to use this command")
+	identityFilePath, err := cmd.Flags().GetString("identityFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --identityFile flag")
+	}
+
+	hostname, err := cmd.Flags().GetString("hostname")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --hostname flag")
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --output flag")
+	}
+
+	if outFilePath == "" && !addToAgent && identityFilePath == "" && output == "" {
+		util.PrintErrorMessageAndExit("You must provide one of --outFilePath, --addToAgent, --identityFile, or --output to use this command")
 	}
 
 	var (
@@ -396,6 +814,144 @@ func issueCredentials(cmd *cobra.Command, args []string) {
 			fmt.Println("The SSH key and certificate have been successfully added to your ssh-agent.")
 		}
 	}
+
+	if identityFilePath != "" {
+		_, cert, _, err := parseCertAndKey(creds.PrivateKey, creds.SignedKey)
+		if err != nil {
+			util.HandleError(err, "Failed to parse issued certificate")
+		}
+
+		var hostnames []string
+		if hostname != "" {
+			hostnames = []string{hostname}
+		}
+
+		meta := identityMetadata{
+			Principals:  principals,
+			Hostnames:   hostnames,
+			CertType:    certType,
+			TTL:         ttl,
+			ValidBefore: int64(cert.ValidBefore),
+		}
+
+		if err := writeIdentityFile(identityFilePath, creds.PrivateKey, creds.SignedKey, "", meta); err != nil {
+			util.HandleError(err, "Failed to write identity file")
+		}
+		fmt.Println("📦 Wrote self-contained identity file to:", identityFilePath)
+	}
+
+	if output != "" && output != "file" {
+		sink, err := newCredentialSink(output, fileCredentialSink{})
+		if err != nil {
+			util.HandleError(err, "Invalid --output flag")
+		}
+
+		material := CredentialMaterial{
+			PrivateKey:  creds.PrivateKey,
+			PublicKey:   creds.PublicKey,
+			Certificate: creds.SignedKey,
+			Principals:  principals,
+		}
+		if validAfter, validBefore, err := parseCertValidity(creds.SignedKey); err == nil {
+			material.ValidAfter = validAfter
+			material.ValidBefore = validBefore
+		}
+
+		if err := sink.Emit(material); err != nil {
+			util.HandleError(err, "Failed to emit SSH credentials")
+		}
+	}
+}
+
+// resolvePassphrase determines the passphrase to use for decrypting a
+// private key, preferring an explicit --passphrase, then --passphrase-env,
+// then --passphrase-file. An empty return means no passphrase was supplied
+// up front; loadPrivateKeySigner will prompt interactively if the key turns
+// out to be encrypted.
+func resolvePassphrase(cmd *cobra.Command) (string, error) {
+	passphrase, err := cmd.Flags().GetString("passphrase")
+	if err != nil {
+		return "", fmt.Errorf("unable to parse --passphrase flag: %w", err)
+	}
+	if passphrase != "" {
+		return passphrase, nil
+	}
+
+	passphraseEnv, err := cmd.Flags().GetString("passphrase-env")
+	if err != nil {
+		return "", fmt.Errorf("unable to parse --passphrase-env flag: %w", err)
+	}
+	if passphraseEnv != "" {
+		if value := os.Getenv(passphraseEnv); value != "" {
+			return value, nil
+		}
+	}
+
+	passphraseFile, err := cmd.Flags().GetString("passphrase-file")
+	if err != nil {
+		return "", fmt.Errorf("unable to parse --passphrase-file flag: %w", err)
+	}
+	if passphraseFile != "" {
+		content, err := os.ReadFile(passphraseFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read --passphrase-file: %w", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	}
+
+	return "", nil
+}
+
+// loadPrivateKeySigner reads the private key at path and returns an
+// ssh.Signer for it along with its decrypted PEM contents. If the key is
+// encrypted and passphrase is empty, the user is prompted interactively,
+// mirroring how werf's ssh-agent helper falls back to terminal.ReadPassword.
+func loadPrivateKeySigner(path, passphrase string) (ssh.Signer, string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read private key file: %w", err)
+	}
+
+	key, err := ssh.ParseRawPrivateKey(content)
+	if err == nil {
+		signer, err := ssh.NewSignerFromKey(key)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to derive signer from private key: %w", err)
+		}
+		return signer, string(content), nil
+	}
+
+	var missingPassphraseErr *ssh.PassphraseMissingError
+	if !errors.As(err, &missingPassphraseErr) {
+		return nil, "", fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	if passphrase == "" {
+		fmt.Printf("Enter passphrase for key '%s': ", path)
+		pwBytes, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		passphrase = string(pwBytes)
+	}
+
+	key, err = ssh.ParseRawPrivateKeyWithPassphrase(content, []byte(passphrase))
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to decrypt private key (wrong passphrase?): %w", err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to derive signer from private key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to re-encode decrypted private key: %w", err)
+	}
+
+	return signer, string(pem.EncodeToMemory(block)), nil
 }
 
 func signKey(cmd *cobra.Command, args []string) {
@@ -450,6 +1006,8 @@ func signKey(cmd *cobra.Command, args []string) {
 		util.HandleError(fmt.Errorf("only one of --publicKey or --publicKeyFile can be provided"), "Invalid input")
 	}
 
+	var decryptedPrivateKey string
+
 	if publicKeyFilePath != "" {
 		if strings.HasPrefix(publicKeyFilePath, "~") {
 			// Expand the tilde (~) to the user's home directory
@@ -460,17 +1018,29 @@ func signKey(cmd *cobra.Command, args []string) {
 			publicKeyFilePath = strings.Replace(publicKeyFilePath, "~", homeDir, 1)
 		}
 
-		// Ensure the file has a .pub extension
-		if !strings.HasSuffix(publicKeyFilePath, ".pub") {
-			util.HandleError(fmt.Errorf("public key file must have a .pub extension"), "Invalid input")
-		}
+		if strings.HasSuffix(publicKeyFilePath, ".pub") {
+			content, err := os.ReadFile(publicKeyFilePath)
+			if err != nil {
+				util.HandleError(err, "Failed to read public key file")
+			}
 
-		content, err := os.ReadFile(publicKeyFilePath)
-		if err != nil {
-			util.HandleError(err, "Failed to read public key file")
-		}
+			publicKey = strings.TrimSpace(string(content))
+		} else {
+			// No .pub extension: treat the path as a private key and derive
+			// the public key from it, prompting for a passphrase if needed.
+			passphrase, err := resolvePassphrase(cmd)
+			if err != nil {
+				util.HandleError(err, "Unable to resolve passphrase")
+			}
 
-		publicKey = strings.TrimSpace(string(content))
+			signer, decrypted, err := loadPrivateKeySigner(publicKeyFilePath, passphrase)
+			if err != nil {
+				util.HandleError(err, "Failed to load private key")
+			}
+
+			publicKey = strings.TrimSpace(string(ssh.MarshalAuthorizedKey(signer.PublicKey())))
+			decryptedPrivateKey = decrypted
+		}
 	}
 
 	if strings.TrimSpace(publicKey) == "" {
@@ -607,9 +1177,54 @@ func signKey(cmd *cobra.Command, args []string) {
 	}
 
 	fmt.Println("Successfully wrote SSH certificate to:", signedKeyPath)
+
+	addToAgent, err := cmd.Flags().GetBool("addToAgent")
+	if err != nil {
+		util.HandleError(err, "Unable to parse addToAgent flag")
+	}
+	if addToAgent {
+		if decryptedPrivateKey == "" {
+			util.PrintErrorMessageAndExit("--addToAgent requires signing a private key via --publicKeyFilePath")
+		}
+		if err := addCredentialsToAgent(decryptedPrivateKey, creds.SignedKey); err != nil {
+			util.HandleError(err, "Failed to add keys to SSH agent")
+		} else {
+			fmt.Println("The SSH key and certificate have been successfully added to your ssh-agent.")
+		}
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --output flag")
+	}
+	if output != "" && output != "file" {
+		sink, err := newCredentialSink(output, fileCredentialSink{})
+		if err != nil {
+			util.HandleError(err, "Invalid --output flag")
+		}
+
+		material := CredentialMaterial{
+			PrivateKey:  decryptedPrivateKey,
+			PublicKey:   publicKey,
+			Certificate: creds.SignedKey,
+			Principals:  principals,
+		}
+		if validAfter, validBefore, err := parseCertValidity(creds.SignedKey); err == nil {
+			material.ValidAfter = validAfter
+			material.ValidBefore = validBefore
+		}
+
+		if err := sink.Emit(material); err != nil {
+			util.HandleError(err, "Failed to emit SSH credentials")
+		}
+	}
 }
 
-func sshConnect(cmd *cobra.Command, args []string) {
+// buildAuthenticatedSshClient resolves the caller's Infisical token (machine
+// identity token, service token, or interactive login session) and returns an
+// SDK client ready to make Ssh() calls. This boilerplate is shared by every
+// `infisical ssh` subcommand that talks to the API.
+func buildAuthenticatedSshClient(cmd *cobra.Command) *infisicalSdk.InfisicalClient {
 	token, err := util.GetInfisicalToken(cmd)
 	if err != nil {
 		util.HandleError(err, "Unable to parse token")
@@ -647,7 +1262,13 @@ func sshConnect(cmd *cobra.Command, args []string) {
 	})
 	infisicalClient.Auth().SetAccessToken(infisicalToken)
 
-	// Fetch SSH Hosts
+	return infisicalClient
+}
+
+// selectSshHostAndLoginUser prompts the user to pick one of their accessible
+// SSH hosts and one of its registered login users. It is shared by every
+// subcommand that needs a target host (connect, sftp, scp, forward).
+func selectSshHostAndLoginUser(infisicalClient *infisicalSdk.InfisicalClient) (infisicalSdk.SshHost, string) {
 	hosts, err := infisicalClient.Ssh().GetSshHosts(infisicalSdk.GetSshHostsOptions{})
 	if err != nil {
 		util.HandleError(err, "Failed to fetch SSH hosts")
@@ -656,7 +1277,6 @@ func sshConnect(cmd *cobra.Command, args []string) {
 		util.PrintErrorMessageAndExit("You do not have access to any SSH hosts")
 	}
 
-	// Prompt to select host
 	hostNames := make([]string, len(hosts))
 	for i, h := range hosts {
 		hostNames[i] = h.Hostname
@@ -673,7 +1293,6 @@ func sshConnect(cmd *cobra.Command, args []string) {
 	}
 	selectedHost := hosts[hostIdx]
 
-	// Prompt to select login user
 	if len(selectedHost.LoginMappings) == 0 {
 		util.PrintErrorMessageAndExit("No login users available for selected host")
 	}
@@ -692,43 +1311,1552 @@ func sshConnect(cmd *cobra.Command, args []string) {
 	if err != nil {
 		util.HandleError(err, "Prompt failed")
 	}
-	selectedLoginUser := selectedHost.LoginMappings[loginIdx].LoginUser
 
-	// Issue SSH creds for host
-	creds, err := infisicalClient.Ssh().IssueSshHostUserCert(selectedHost.ID, infisicalSdk.IssueSshHostUserCertOptions{
-		LoginUser: selectedLoginUser,
-	})
-	if err != nil {
-		util.HandleError(err, "Failed to issue SSH credentials")
+	return selectedHost, selectedHost.LoginMappings[loginIdx].LoginUser
+}
+
+func sshConnect(cmd *cobra.Command, args []string) {
+	identityFilePath, err := cmd.Flags().GetString("identityFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --identityFile flag")
+	}
+	if identityFilePath != "" {
+		sshConnectWithIdentityFile(cmd, identityFilePath)
+		return
+	}
+
+	infisicalClient := buildAuthenticatedSshClient(cmd)
+	selectedHost, selectedLoginUser := selectSshHostAndLoginUser(infisicalClient)
+
+	// Issue SSH creds for host
+	creds, err := infisicalClient.Ssh().IssueSshHostUserCert(selectedHost.ID, infisicalSdk.IssueSshHostUserCertOptions{
+		LoginUser: selectedLoginUser,
+	})
+	if err != nil {
+		util.HandleError(err, "Failed to issue SSH credentials")
 	}
 
 	// Load credentials into SSH agent
 	err = addCredentialsToAgent(creds.PrivateKey, creds.SignedKey)
 	if err != nil {
-		util.HandleError(err, "Failed to add credentials to SSH agent")
+		util.HandleError(err, "Failed to add credentials to SSH agent")
+	}
+	fmt.Println("✔ SSH credentials successfully added to agent")
+
+	bastionHost, err := cmd.Flags().GetString("bastionHost")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --bastionHost flag")
+	}
+
+	insecure, err := cmd.Flags().GetBool("insecure")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --insecure flag")
+	}
+
+	target := fmt.Sprintf("%s@%s", selectedLoginUser, selectedHost.Hostname)
+
+	if bastionHost == "" {
+		fmt.Printf("Connecting to %s...\n", target)
+
+		agentClient, err := connectToLocalAgent()
+		if err != nil {
+			util.HandleError(err, "Failed to connect to SSH agent")
+		}
+
+		signers, err := agentClient.Signers()
+		if err != nil {
+			util.HandleError(err, "Failed to list SSH agent signers")
+		}
+
+		var hostKeyCallback ssh.HostKeyCallback
+		caPublicKey, err := infisicalClient.Ssh().GetSshHostHostCaPublicKey(selectedHost.ID)
+		if err == nil {
+			hostKeyCallback, err = hostCaHostKeyCallback(caPublicKey)
+		}
+		if err != nil {
+			if !insecure {
+				util.HandleError(err, "Failed to set up host certificate validation. Pass --insecure to proceed without it")
+			}
+			fmt.Printf("⚠ Could not validate the host's Host CA, falling back to no host key checking: %v\n", err)
+			hostKeyCallback = ssh.InsecureIgnoreHostKey()
+		}
+
+		clientConfig := &ssh.ClientConfig{
+			User: selectedLoginUser,
+			Auth: []ssh.AuthMethod{ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+				return signers, nil
+			})},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         10 * time.Second,
+		}
+
+		addr := net.JoinHostPort(selectedHost.Hostname, "22")
+		client, err := ssh.Dial("tcp", addr, clientConfig)
+		if err != nil {
+			util.HandleError(err, fmt.Sprintf("Failed to connect to %s", addr))
+		}
+		defer client.Close()
+
+		if err := runInteractiveSession(client); err != nil {
+			util.HandleError(err, "SSH connection failed")
+		}
+		return
+	}
+
+	bastionUser, err := cmd.Flags().GetString("bastionUser")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --bastionUser flag")
+	}
+	if bastionUser == "" {
+		bastionUser = selectedLoginUser
+	}
+
+	bastionPort, err := cmd.Flags().GetInt("bastionPort")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --bastionPort flag")
+	}
+
+	bastionCertificateTemplateId, err := cmd.Flags().GetString("bastionCertificateTemplateId")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --bastionCertificateTemplateId flag")
+	}
+	if bastionCertificateTemplateId == "" {
+		util.PrintErrorMessageAndExit("You must provide --bastionCertificateTemplateId when using --bastionHost")
+	}
+
+	// Issue a second, short-lived user cert for the bastion itself
+	bastionCreds, err := infisicalClient.Ssh().IssueCredentials(infisicalSdk.IssueSshCredsOptions{
+		CertificateTemplateID: bastionCertificateTemplateId,
+		Principals:            []string{bastionUser},
+		KeyAlgorithm:          infisicalSdkUtil.RSA2048,
+		CertType:              infisicalSdkUtil.UserCert,
+	})
+	if err != nil {
+		util.HandleError(err, "Failed to issue SSH credentials for bastion host")
+	}
+
+	// Keep the bastion cert out of the user's real ssh-agent; it only needs
+	// to live long enough to establish the tunnel to the target host.
+	bastionKeyring := agent.NewKeyring()
+	if err := addCredentialsToKeyring(bastionKeyring, bastionCreds.PrivateKey, bastionCreds.SignedKey); err != nil {
+		util.HandleError(err, "Failed to load bastion credentials into in-memory agent")
+	}
+	if err := addCredentialsToKeyring(bastionKeyring, creds.PrivateKey, creds.SignedKey); err != nil {
+		util.HandleError(err, "Failed to load target credentials into in-memory agent")
+	}
+
+	bastionAddr := fmt.Sprintf("%s:%d", bastionHost, bastionPort)
+	fmt.Printf("Connecting to %s via bastion %s@%s...\n", target, bastionUser, bastionAddr)
+
+	var targetHostKeyCallback ssh.HostKeyCallback
+	caPublicKey, err := infisicalClient.Ssh().GetSshHostHostCaPublicKey(selectedHost.ID)
+	if err == nil {
+		targetHostKeyCallback, err = hostCaHostKeyCallback(caPublicKey)
+	}
+	if err != nil {
+		if !insecure {
+			util.HandleError(err, "Failed to set up host certificate validation. Pass --insecure to proceed without it")
+		}
+		fmt.Printf("⚠ Could not validate the host's Host CA, falling back to no host key checking: %v\n", err)
+		targetHostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+
+	targetAddr := net.JoinHostPort(selectedHost.Hostname, "22")
+	targetClient, err := dialThroughBastion(bastionAddr, bastionUser, selectedLoginUser, targetAddr, bastionKeyring, nil, targetHostKeyCallback)
+	if err != nil {
+		util.HandleError(err, "Failed to connect to target host through bastion")
+	}
+	defer targetClient.Close()
+
+	if err := runInteractiveSession(targetClient); err != nil {
+		util.HandleError(err, "SSH connection failed")
+	}
+}
+
+// sshConnectWithIdentityFile seeds a connection entirely from a previously
+// exported identity file, skipping the login and credential-issuance
+// round-trip. This is the path CI jobs and other non-interactive callers are
+// expected to use.
+func sshConnectWithIdentityFile(cmd *cobra.Command, identityFilePath string) {
+	if strings.HasPrefix(identityFilePath, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			util.HandleError(err, "Failed to resolve home directory")
+		}
+		identityFilePath = strings.Replace(identityFilePath, "~", homeDir, 1)
+	}
+
+	privateKey, signedCert, _, meta, err := readIdentityFile(identityFilePath)
+	if err != nil {
+		util.HandleError(err, "Failed to read identity file")
+	}
+
+	hostname, err := cmd.Flags().GetString("hostname")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --hostname flag")
+	}
+	if hostname == "" && len(meta.Hostnames) == 1 {
+		hostname = meta.Hostnames[0]
+	}
+	if hostname == "" {
+		util.PrintErrorMessageAndExit("Could not determine the target hostname from the identity file. Pass --hostname explicitly")
+	}
+
+	loginUser, err := cmd.Flags().GetString("loginUser")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --loginUser flag")
+	}
+	if loginUser == "" && len(meta.Principals) > 0 {
+		loginUser = meta.Principals[0]
+	}
+	if loginUser == "" {
+		util.PrintErrorMessageAndExit("Could not determine the login user from the identity file. Pass --loginUser explicitly")
+	}
+
+	if err := addCredentialsToAgent(privateKey, signedCert); err != nil {
+		util.HandleError(err, "Failed to add identity file credentials to SSH agent")
+	}
+	fmt.Println("✔ SSH credentials from identity file successfully added to agent")
+
+	target := fmt.Sprintf("%s@%s", loginUser, hostname)
+	fmt.Printf("Connecting to %s...\n", target)
+
+	sshCmd := exec.Command("ssh", target)
+	sshCmd.Stdin = os.Stdin
+	sshCmd.Stdout = os.Stdout
+	sshCmd.Stderr = os.Stderr
+
+	if err := sshCmd.Run(); err != nil {
+		util.HandleError(err, "SSH connection failed")
+	}
+}
+
+// dialThroughBastion opens an SSH connection to bastionAddr, then tunnels a
+// second SSH connection to targetAddr through it. This mirrors how Terraform's
+// SSH communicator traverses a bastion host: dial the bastion, open a TCP
+// stream to the target over that connection, and perform a second SSH
+// handshake on top of it.
+func dialThroughBastion(bastionAddr, bastionUser, targetUser, targetAddr string, signers agent.Agent, bastionHostKeyCallback, targetHostKeyCallback ssh.HostKeyCallback) (*ssh.Client, error) {
+	authSigners, err := signers.Signers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list in-memory agent signers: %w", err)
+	}
+
+	authMethod := ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+		return authSigners, nil
+	})
+
+	if bastionHostKeyCallback == nil {
+		bastionHostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+	if targetHostKeyCallback == nil {
+		targetHostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+
+	bastionConfig := &ssh.ClientConfig{
+		User:            bastionUser,
+		Auth:            []ssh.AuthMethod{authMethod},
+		HostKeyCallback: bastionHostKeyCallback,
+		Timeout:         10 * time.Second,
+	}
+
+	bastionClient, err := ssh.Dial("tcp", bastionAddr, bastionConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial bastion host %s: %w", bastionAddr, err)
+	}
+
+	conn, err := bastionClient.Dial("tcp", targetAddr)
+	if err != nil {
+		bastionClient.Close()
+		return nil, fmt.Errorf("failed to reach %s through bastion: %w", targetAddr, err)
+	}
+
+	targetConfig := &ssh.ClientConfig{
+		User:            targetUser,
+		Auth:            []ssh.AuthMethod{authMethod},
+		HostKeyCallback: targetHostKeyCallback,
+		Timeout:         10 * time.Second,
+	}
+
+	ncc, chans, reqs, err := ssh.NewClientConn(conn, targetAddr, targetConfig)
+	if err != nil {
+		conn.Close()
+		bastionClient.Close()
+		return nil, fmt.Errorf("failed to establish SSH connection to %s: %w", targetAddr, err)
+	}
+
+	return ssh.NewClient(ncc, chans, reqs), nil
+}
+
+// hostCaHostKeyCallback builds an ssh.HostKeyCallback that accepts a host
+// certificate only if it was signed by caPublicKey, the connecting hostname
+// is among the certificate's principals, and the certificate has not expired.
+// It delegates to ssh.CertChecker, which already implements this validation
+// for host certificates.
+func hostCaHostKeyCallback(caPublicKey string) (ssh.HostKeyCallback, error) {
+	caKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(caPublicKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse Host CA public key: %w", err)
+	}
+
+	checker := &ssh.CertChecker{
+		IsHostAuthority: func(auth ssh.PublicKey, address string) bool {
+			return bytes.Equal(auth.Marshal(), caKey.Marshal())
+		},
+	}
+
+	return checker.CheckHostKey, nil
+}
+
+// dialSshHostEphemeral issues a short-lived user certificate for host and
+// loginUser, loads it into an in-memory agent only (never the user's real
+// ssh-agent, never written to disk), and dials the host directly with it.
+// This is the shared connection path for sftp/scp/forward, which only need
+// the certificate for the lifetime of a single command.
+func dialSshHostEphemeral(infisicalClient *infisicalSdk.InfisicalClient, host infisicalSdk.SshHost, loginUser string, insecure bool) *ssh.Client {
+	creds, err := infisicalClient.Ssh().IssueSshHostUserCert(host.ID, infisicalSdk.IssueSshHostUserCertOptions{
+		LoginUser: loginUser,
+	})
+	if err != nil {
+		util.HandleError(err, "Failed to issue SSH credentials")
+	}
+
+	keyring := agent.NewKeyring()
+	if err := addCredentialsToKeyring(keyring, creds.PrivateKey, creds.SignedKey); err != nil {
+		util.HandleError(err, "Failed to load credentials into in-memory agent")
+	}
+
+	signers, err := keyring.Signers()
+	if err != nil {
+		util.HandleError(err, "Failed to list in-memory agent signers")
+	}
+
+	var hostKeyCallback ssh.HostKeyCallback
+	caPublicKey, err := infisicalClient.Ssh().GetSshHostHostCaPublicKey(host.ID)
+	if err == nil {
+		hostKeyCallback, err = hostCaHostKeyCallback(caPublicKey)
+	}
+	if err != nil {
+		if !insecure {
+			util.HandleError(err, "Failed to set up host certificate validation. Pass --insecure to proceed without it")
+		}
+		fmt.Printf("⚠ Could not validate the host's Host CA, falling back to no host key checking: %v\n", err)
+		hostKeyCallback = ssh.InsecureIgnoreHostKey()
+	}
+
+	clientConfig := &ssh.ClientConfig{
+		User: loginUser,
+		Auth: []ssh.AuthMethod{ssh.PublicKeysCallback(func() ([]ssh.Signer, error) {
+			return signers, nil
+		})},
+		HostKeyCallback: hostKeyCallback,
+		Timeout:         10 * time.Second,
+	}
+
+	addr := net.JoinHostPort(host.Hostname, "22")
+	client, err := ssh.Dial("tcp", addr, clientConfig)
+	if err != nil {
+		util.HandleError(err, fmt.Sprintf("Failed to connect to %s", addr))
+	}
+
+	return client
+}
+
+func sftpDownload(client *sftp.Client, remotePath, localPath string) error {
+	remoteFile, err := client.Open(remotePath)
+	if err != nil {
+		return fmt.Errorf("failed to open remote file: %w", err)
+	}
+	defer remoteFile.Close()
+
+	if dir := filepath.Dir(localPath); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create local directory: %w", err)
+		}
+	}
+
+	localFile, err := os.Create(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to create local file: %w", err)
+	}
+	defer localFile.Close()
+
+	_, err = io.Copy(localFile, remoteFile)
+	return err
+}
+
+func sftpUpload(client *sftp.Client, localPath, remotePath string) error {
+	localFile, err := os.Open(localPath)
+	if err != nil {
+		return fmt.Errorf("failed to open local file: %w", err)
+	}
+	defer localFile.Close()
+
+	remoteFile, err := client.Create(remotePath)
+	if err != nil {
+		return fmt.Errorf("failed to create remote file: %w", err)
+	}
+	defer remoteFile.Close()
+
+	_, err = io.Copy(remoteFile, localFile)
+	return err
+}
+
+func sshSftp(cmd *cobra.Command, args []string) {
+	getPath, err := cmd.Flags().GetString("get")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --get flag")
+	}
+	outPath, err := cmd.Flags().GetString("out")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --out flag")
+	}
+	putPath, err := cmd.Flags().GetString("put")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --put flag")
+	}
+	remotePath, err := cmd.Flags().GetString("remote")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --remote flag")
+	}
+
+	if (getPath == "") == (putPath == "") {
+		util.PrintErrorMessageAndExit("You must provide exactly one of --get or --put")
+	}
+	if getPath != "" && outPath == "" {
+		util.PrintErrorMessageAndExit("--out is required with --get")
+	}
+	if putPath != "" && remotePath == "" {
+		util.PrintErrorMessageAndExit("--remote is required with --put")
+	}
+
+	insecure, err := cmd.Flags().GetBool("insecure")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --insecure flag")
+	}
+
+	infisicalClient := buildAuthenticatedSshClient(cmd)
+	host, loginUser := selectSshHostAndLoginUser(infisicalClient)
+
+	sshClient := dialSshHostEphemeral(infisicalClient, host, loginUser, insecure)
+	defer sshClient.Close()
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		util.HandleError(err, "Failed to start SFTP session")
+	}
+	defer sftpClient.Close()
+
+	if getPath != "" {
+		if err := sftpDownload(sftpClient, getPath, outPath); err != nil {
+			util.HandleError(err, "Failed to download file")
+		}
+		fmt.Printf("✔ Downloaded %s:%s to %s\n", host.Hostname, getPath, outPath)
+	} else {
+		if err := sftpUpload(sftpClient, putPath, remotePath); err != nil {
+			util.HandleError(err, "Failed to upload file")
+		}
+		fmt.Printf("✔ Uploaded %s to %s:%s\n", putPath, host.Hostname, remotePath)
+	}
+}
+
+func sshScp(cmd *cobra.Command, args []string) {
+	src, dst := args[0], args[1]
+	srcIsRemote := strings.HasPrefix(src, "remote:")
+	dstIsRemote := strings.HasPrefix(dst, "remote:")
+
+	if srcIsRemote == dstIsRemote {
+		util.PrintErrorMessageAndExit(`Exactly one of <source>/<destination> must be prefixed with "remote:" to mark the Infisical-managed host side of the copy`)
+	}
+
+	insecure, err := cmd.Flags().GetBool("insecure")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --insecure flag")
+	}
+
+	infisicalClient := buildAuthenticatedSshClient(cmd)
+	host, loginUser := selectSshHostAndLoginUser(infisicalClient)
+
+	sshClient := dialSshHostEphemeral(infisicalClient, host, loginUser, insecure)
+	defer sshClient.Close()
+
+	sftpClient, err := sftp.NewClient(sshClient)
+	if err != nil {
+		util.HandleError(err, "Failed to start SFTP session")
+	}
+	defer sftpClient.Close()
+
+	if srcIsRemote {
+		remotePath := strings.TrimPrefix(src, "remote:")
+		if err := sftpDownload(sftpClient, remotePath, dst); err != nil {
+			util.HandleError(err, "Failed to download file")
+		}
+		fmt.Printf("✔ Copied %s:%s to %s\n", host.Hostname, remotePath, dst)
+	} else {
+		remotePath := strings.TrimPrefix(dst, "remote:")
+		if err := sftpUpload(sftpClient, src, remotePath); err != nil {
+			util.HandleError(err, "Failed to upload file")
+		}
+		fmt.Printf("✔ Copied %s to %s:%s\n", src, host.Hostname, remotePath)
+	}
+}
+
+// parseForwardSpec parses an OpenSSH-style forward spec of the form
+// "[bind_address:]port:host:hostport" into the address to listen on and the
+// address to connect to on the other side.
+func parseForwardSpec(spec string) (bindAddr, destAddr string, err error) {
+	parts := strings.Split(spec, ":")
+	switch len(parts) {
+	case 3:
+		return net.JoinHostPort("localhost", parts[0]), net.JoinHostPort(parts[1], parts[2]), nil
+	case 4:
+		return net.JoinHostPort(parts[0], parts[1]), net.JoinHostPort(parts[2], parts[3]), nil
+	default:
+		return "", "", fmt.Errorf(`invalid forward spec %q, expected "[bind_address:]port:host:hostport"`, spec)
+	}
+}
+
+// proxyConn pipes data in both directions between a and b until either side
+// closes, then closes both.
+func proxyConn(a, b net.Conn) {
+	defer a.Close()
+	defer b.Close()
+
+	done := make(chan struct{}, 2)
+	go func() {
+		io.Copy(a, b)
+		done <- struct{}{}
+	}()
+	go func() {
+		io.Copy(b, a)
+		done <- struct{}{}
+	}()
+	<-done
+}
+
+func runLocalForward(client *ssh.Client, spec string) {
+	bindAddr, destAddr, err := parseForwardSpec(spec)
+	if err != nil {
+		util.HandleError(err, "Invalid -L forward spec")
+	}
+
+	listener, err := net.Listen("tcp", bindAddr)
+	if err != nil {
+		util.HandleError(err, "Failed to listen for local forward")
+	}
+	defer listener.Close()
+
+	fmt.Printf("↔ Forwarding %s -> %s (through the SSH host)\n", bindAddr, destAddr)
+
+	for {
+		localConn, err := listener.Accept()
+		if err != nil {
+			util.HandleError(err, "Local forward listener failed")
+		}
+
+		go func() {
+			remoteConn, err := client.Dial("tcp", destAddr)
+			if err != nil {
+				fmt.Printf("⚠ Failed to dial %s through host: %v\n", destAddr, err)
+				localConn.Close()
+				return
+			}
+			proxyConn(localConn, remoteConn)
+		}()
+	}
+}
+
+func runRemoteForward(client *ssh.Client, spec string) {
+	bindAddr, destAddr, err := parseForwardSpec(spec)
+	if err != nil {
+		util.HandleError(err, "Invalid -R forward spec")
+	}
+
+	listener, err := client.Listen("tcp", bindAddr)
+	if err != nil {
+		util.HandleError(err, "Failed to listen for remote forward on host")
+	}
+	defer listener.Close()
+
+	fmt.Printf("↔ Forwarding host:%s -> %s\n", bindAddr, destAddr)
+
+	for {
+		remoteConn, err := listener.Accept()
+		if err != nil {
+			util.HandleError(err, "Remote forward listener failed")
+		}
+
+		go func() {
+			localConn, err := net.Dial("tcp", destAddr)
+			if err != nil {
+				fmt.Printf("⚠ Failed to dial local %s: %v\n", destAddr, err)
+				remoteConn.Close()
+				return
+			}
+			proxyConn(remoteConn, localConn)
+		}()
+	}
+}
+
+func sshForward(cmd *cobra.Command, args []string) {
+	localSpec, err := cmd.Flags().GetString("local")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --local flag")
+	}
+	remoteSpec, err := cmd.Flags().GetString("remote")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --remote flag")
+	}
+
+	if (localSpec == "") == (remoteSpec == "") {
+		util.PrintErrorMessageAndExit("You must provide exactly one of --local (-L) or --remote (-R)")
+	}
+
+	insecure, err := cmd.Flags().GetBool("insecure")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --insecure flag")
+	}
+
+	infisicalClient := buildAuthenticatedSshClient(cmd)
+	host, loginUser := selectSshHostAndLoginUser(infisicalClient)
+
+	sshClient := dialSshHostEphemeral(infisicalClient, host, loginUser, insecure)
+	defer sshClient.Close()
+
+	if localSpec != "" {
+		runLocalForward(sshClient, localSpec)
+	} else {
+		runRemoteForward(sshClient, remoteSpec)
+	}
+}
+
+// runInteractiveSession opens an interactive shell on client, wiring the
+// local terminal's stdin/stdout/stderr to the remote session and restoring
+// the terminal state on exit.
+func runInteractiveSession(client *ssh.Client) error {
+	session, err := client.NewSession()
+	if err != nil {
+		return fmt.Errorf("failed to create SSH session: %w", err)
+	}
+	defer session.Close()
+
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+
+		oldState, err := term.MakeRaw(fd)
+		if err == nil {
+			defer term.Restore(fd, oldState)
+		}
+
+		modes := ssh.TerminalModes{
+			ssh.ECHO:          1,
+			ssh.TTY_OP_ISPEED: 14400,
+			ssh.TTY_OP_OSPEED: 14400,
+		}
+		termType := os.Getenv("TERM")
+		if termType == "" {
+			termType = "xterm-256color"
+		}
+		if err := session.RequestPty(termType, height, width, modes); err != nil {
+			return fmt.Errorf("failed to request pty: %w", err)
+		}
+	}
+
+	session.Stdin = os.Stdin
+	session.Stdout = os.Stdout
+	session.Stderr = os.Stderr
+
+	if err := session.Shell(); err != nil {
+		return fmt.Errorf("failed to start shell: %w", err)
+	}
+
+	return session.Wait()
+}
+
+// autodiscoverPrincipals resolves this machine's local hostname plus the
+// reverse-DNS names of every non-loopback interface address, for use with
+// --autodiscover. The local hostname comes first.
+func autodiscoverPrincipals() ([]string, error) {
+	seen := map[string]bool{}
+	var names []string
+
+	add := func(name string) {
+		name = strings.TrimSuffix(strings.TrimSpace(name), ".")
+		if name == "" || seen[name] {
+			return
+		}
+		seen[name] = true
+		names = append(names, name)
+	}
+
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve local hostname: %w", err)
+	}
+	add(hostname)
+
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list network interfaces: %w", err)
+	}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok || ipNet.IP.IsLoopback() {
+			continue
+		}
+		resolved, err := net.LookupAddr(ipNet.IP.String())
+		if err != nil {
+			continue
+		}
+		for _, name := range resolved {
+			add(name)
+		}
+	}
+
+	return names, nil
+}
+
+func sshAddHost(cmd *cobra.Command, args []string) {
+
+	token, err := util.GetInfisicalToken(cmd)
+	if err != nil {
+		util.HandleError(err, "Unable to parse token")
+	}
+
+	var infisicalToken string
+	if token != nil && (token.Type == util.SERVICE_TOKEN_IDENTIFIER || token.Type == util.UNIVERSAL_AUTH_TOKEN_IDENTIFIER) {
+		infisicalToken = token.Token
+	} else {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+
+		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails(true)
+		if err != nil {
+			util.HandleError(err, "Unable to authenticate")
+		}
+		if loggedInUserDetails.LoginExpired {
+			util.PrintErrorMessageAndExit("Your login session has expired, please run [infisical login]")
+		}
+		infisicalToken = loggedInUserDetails.UserCredentials.JTWToken
+	}
+
+	projectId, err := cmd.Flags().GetString("projectId")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --projectId flag")
+	}
+	if projectId == "" {
+		util.PrintErrorMessageAndExit("You must provide --projectId")
+	}
+
+	hostname, err := cmd.Flags().GetString("hostname")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --hostname flag")
+	}
+
+	autodiscover, err := cmd.Flags().GetBool("autodiscover")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --autodiscover flag")
+	}
+
+	var extraDiscoveredNames []string
+	if hostname == "" {
+		if !autodiscover {
+			util.PrintErrorMessageAndExit("You must provide --hostname or pass --autodiscover")
+		}
+		discovered, err := autodiscoverPrincipals()
+		if err != nil {
+			util.HandleError(err, "Failed to autodiscover hostname")
+		}
+		if len(discovered) == 0 {
+			util.PrintErrorMessageAndExit("--autodiscover could not resolve any hostnames for this machine")
+		}
+		hostname = discovered[0]
+		extraDiscoveredNames = discovered[1:]
+		fmt.Println("🔍 Autodiscovered hostname:", hostname)
+	} else if autodiscover {
+		discovered, err := autodiscoverPrincipals()
+		if err != nil {
+			util.HandleError(err, "Failed to autodiscover additional principals")
+		}
+		extraDiscoveredNames = discovered
+	}
+
+	// The SDK's AddSshHostOptions has no field to register additional
+	// principals alongside a host, so the extra reverse-DNS names --autodiscover
+	// finds beyond the primary hostname can only be surfaced, not registered.
+	if len(extraDiscoveredNames) > 0 {
+		fmt.Println("ℹ Also discovered (not registered — add-host has no field for extra principals yet):", strings.Join(extraDiscoveredNames, ", "))
+	}
+
+	writeKnownHostsCaLine, err := cmd.Flags().GetBool("writeKnownHostsCaLine")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --writeKnownHostsCaLine flag")
+	}
+
+	knownHostsCaFile, err := cmd.Flags().GetString("knownHostsCaFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --knownHostsCaFile flag")
+	}
+	if strings.HasPrefix(knownHostsCaFile, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			util.HandleError(err, "Unable to resolve ~ in knownHostsCaFile")
+		}
+		knownHostsCaFile = strings.Replace(knownHostsCaFile, "~", homeDir, 1)
+	}
+
+	writeUserCaToFile, err := cmd.Flags().GetBool("writeUserCaToFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --writeUserCaToFile flag")
+	}
+
+	userCaOutFilePath, err := cmd.Flags().GetString("userCaOutFilePath")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --userCaOutFilePath flag")
+	}
+
+	writeHostCertToFile, err := cmd.Flags().GetBool("writeHostCertToFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --writeHostCertToFile flag")
+	}
+
+	configureSshd, err := cmd.Flags().GetBool("configureSshd")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --configureSshd flag")
+	}
+
+	configureSshdDropin, err := cmd.Flags().GetBool("configureSshdDropin")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --configureSshdDropin flag")
+	}
+
+	dropInPath, err := cmd.Flags().GetString("dropInPath")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --dropInPath flag")
+	}
+
+	authorizedPrincipalsDir, err := cmd.Flags().GetString("authorizedPrincipalsDir")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --authorizedPrincipalsDir flag")
+	}
+
+	reloadSshdAfterConfigure, err := cmd.Flags().GetBool("reload")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --reload flag")
+	}
+
+	output, err := cmd.Flags().GetString("output")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --output flag")
+	}
+
+	forceOverwrite, err := cmd.Flags().GetBool("force")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --force flag")
+	}
+
+	if output != "" && output != "file" && !writeUserCaToFile && !writeHostCertToFile {
+		util.PrintErrorMessageAndExit("--output requires --writeUserCaToFile and/or --writeHostCertToFile so there is material to emit")
+	}
+
+	if output == "agent" {
+		util.PrintErrorMessageAndExit("--output=agent is not supported for add-host: a host certificate has no private key for add-host to load into the agent")
+	}
+
+	if configureSshd && configureSshdDropin {
+		util.PrintErrorMessageAndExit("--configureSshd and --configureSshdDropin are mutually exclusive: most distros' sshd_config Includes sshd_config.d/*.conf before reaching the managed block, so the drop-in would silently take precedence. Pick one.")
+	}
+
+	if configureSshd && (!writeUserCaToFile || !writeHostCertToFile) {
+		util.PrintErrorMessageAndExit("--configureSshd requires both --writeUserCaToFile and --writeHostCertToFile to also be set")
+	}
+
+	if configureSshdDropin && (!writeUserCaToFile || !writeHostCertToFile) {
+		util.PrintErrorMessageAndExit("--configureSshdDropin requires both --writeUserCaToFile and --writeHostCertToFile to also be set")
+	}
+
+	// Pre-check for file overwrites before proceeding
+	if writeUserCaToFile {
+		if strings.HasPrefix(userCaOutFilePath, "~") {
+			homeDir, err := os.UserHomeDir()
+			if err != nil {
+				util.HandleError(err, "Unable to resolve ~ in userCaOutFilePath")
+			}
+			userCaOutFilePath = strings.Replace(userCaOutFilePath, "~", homeDir, 1)
+		}
+		if _, err := os.Stat(userCaOutFilePath); err == nil && !forceOverwrite {
+			util.PrintErrorMessageAndExit("File already exists at " + userCaOutFilePath + ". Use --force to overwrite.")
+		}
+	}
+
+	keyTypesFlag, err := cmd.Flags().GetString("keyTypes")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --keyTypes flag")
+	}
+	keyTypes := []string{"ed25519", "ecdsa", "rsa"}
+	if keyTypesFlag != "" {
+		keyTypes = nil
+		for _, keyType := range strings.Split(keyTypesFlag, ",") {
+			if keyType = strings.TrimSpace(keyType); keyType != "" {
+				keyTypes = append(keyTypes, keyType)
+			}
+		}
+	}
+
+	var hostKeyPairs []hostKeyCertPair
+	if writeHostCertToFile {
+		for _, keyType := range keyTypes {
+			pub := fmt.Sprintf("/etc/ssh/ssh_host_%s_key.pub", keyType)
+			if _, err := os.Stat(pub); err != nil {
+				continue
+			}
+
+			cert := fmt.Sprintf("/etc/ssh/ssh_host_%s_key-cert.pub", keyType)
+			if _, err := os.Stat(cert); err == nil && !forceOverwrite {
+				util.PrintErrorMessageAndExit("File already exists at " + cert + ". Use --force to overwrite.")
+			}
+
+			hostKeyPairs = append(hostKeyPairs, hostKeyCertPair{
+				KeyType:        keyType,
+				PubKeyPath:     pub,
+				CertPath:       cert,
+				PrivateKeyPath: fmt.Sprintf("/etc/ssh/ssh_host_%s_key", keyType),
+			})
+		}
+
+		if len(hostKeyPairs) == 0 {
+			util.PrintErrorMessageAndExit("No supported SSH host public key found at /etc/ssh")
+		}
+	}
+
+	customHeaders, err := util.GetInfisicalCustomHeadersMap()
+	if err != nil {
+		util.HandleError(err, "Unable to get custom headers")
+	}
+
+	client := infisicalSdk.NewInfisicalClient(context.Background(), infisicalSdk.Config{
+		SiteUrl:          config.INFISICAL_URL,
+		UserAgent:        api.USER_AGENT,
+		AutoTokenRefresh: false,
+		CustomHeaders:    customHeaders,
+	})
+	client.Auth().SetAccessToken(infisicalToken)
+
+	host, err := client.Ssh().AddSshHost(infisicalSdk.AddSshHostOptions{
+		ProjectID: projectId,
+		Hostname:  hostname,
+	})
+	if err != nil {
+		util.HandleError(err, "Failed to register SSH host")
+	}
+
+	fmt.Println("✅ Successfully registered host:", host.Hostname)
+
+	if writeKnownHostsCaLine {
+		hostCaPublicKey, err := client.Ssh().GetSshHostHostCaPublicKey(host.ID)
+		if err != nil {
+			util.HandleError(err, "Failed to fetch the host's Host CA public key")
+		}
+
+		pattern := "*"
+		if parts := strings.SplitN(hostname, ".", 2); len(parts) == 2 {
+			pattern = "*." + parts[1]
+		}
+
+		marker := infisicalCaMarker(host.ID)
+		caLine := fmt.Sprintf("@cert-authority %s %s %s", pattern, strings.TrimSpace(hostCaPublicKey), marker)
+
+		existing, err := os.ReadFile(knownHostsCaFile)
+		if err != nil && !os.IsNotExist(err) {
+			util.HandleError(err, "Failed to read known_hosts file")
+		}
+
+		var lines []string
+		if len(existing) > 0 {
+			lines = strings.Split(strings.TrimRight(string(existing), "\n"), "\n")
+		}
+
+		replaced := false
+		for i, line := range lines {
+			if strings.HasSuffix(strings.TrimSpace(line), marker) {
+				lines[i] = caLine
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			lines = append(lines, caLine)
+		}
+
+		if err := writeToFile(knownHostsCaFile, strings.Join(lines, "\n")+"\n", 0644); err != nil {
+			util.HandleError(err, "Failed to update known_hosts file")
+		}
+		fmt.Println("📁 Wrote Host CA trust anchor to:", knownHostsCaFile)
+	}
+
+	var userCaPublicKey string
+	issuedHostCerts := map[string]string{}
+
+	if writeUserCaToFile {
+		publicKey, err := client.Ssh().GetSshHostUserCaPublicKey(host.ID)
+		if err != nil {
+			util.HandleError(err, "Failed to fetch associated User CA public key")
+		}
+		userCaPublicKey = publicKey
+
+		if err := writeToFile(userCaOutFilePath, publicKey, 0644); err != nil {
+			util.HandleError(err, "Failed to write User CA public key to file")
+		}
+
+		fmt.Println("📁 Wrote User CA public key to:", userCaOutFilePath)
+	}
+
+	if writeHostCertToFile {
+		for _, pair := range hostKeyPairs {
+			pubKeyBytes, err := os.ReadFile(pair.PubKeyPath)
+			if err != nil {
+				util.HandleError(err, "Failed to read SSH host public key")
+			}
+			res, err := client.Ssh().IssueSshHostHostCert(host.ID, infisicalSdk.IssueSshHostHostCertOptions{
+				PublicKey: string(pubKeyBytes),
+			})
+			if err != nil {
+				util.HandleError(err, "Failed to issue SSH host certificate")
+			}
+			issuedHostCerts[pair.KeyType] = res.SignedKey
+			if err := writeToFile(pair.CertPath, res.SignedKey, 0644); err != nil {
+				util.HandleError(err, "Failed to write SSH host certificate to file")
+			}
+			fmt.Println("📁 Wrote host certificate to:", pair.CertPath)
+		}
+	}
+
+	if configureSshd {
+		sshdConfig := "/etc/ssh/sshd_config"
+		existing, err := os.ReadFile(sshdConfig)
+		if err != nil {
+			util.HandleError(err, "Failed to read sshd_config")
+		}
+
+		block := buildInfisicalSshdManagedBlock(userCaOutFilePath, hostKeyPairs)
+		updated, err := replaceManagedSshdConfigBlock(string(existing), block)
+		if err != nil {
+			util.HandleError(err, "Failed to update sshd_config")
+		}
+
+		if err := writeValidatedSshdConfig(sshdConfig, updated); err != nil {
+			util.HandleError(err, "Failed to update sshd_config")
+		}
+		fmt.Println("📄 Updated sshd_config entries")
+	}
+
+	if configureSshdDropin {
+		loginUsers := make([]string, len(host.LoginMappings))
+		for i, m := range host.LoginMappings {
+			loginUsers[i] = m.LoginUser
+		}
+
+		if len(loginUsers) > 0 {
+			if err := os.MkdirAll(authorizedPrincipalsDir, 0755); err != nil {
+				util.HandleError(err, "Failed to create authorized_principals directory")
+			}
+			if err := writeAuthorizedPrincipalsFiles(authorizedPrincipalsDir, loginUsers); err != nil {
+				util.HandleError(err, "Failed to write authorized_principals files")
+			}
+			fmt.Println("📁 Wrote authorized_principals files to:", authorizedPrincipalsDir)
+		}
+
+		dropInLines := []string{"TrustedUserCAKeys " + userCaOutFilePath}
+		for _, pair := range hostKeyPairs {
+			dropInLines = append(dropInLines, "HostKey "+pair.PrivateKeyPath, "HostCertificate "+pair.CertPath)
+		}
+		dropInLines = append(dropInLines, "AuthorizedPrincipalsFile "+filepath.Join(authorizedPrincipalsDir, "%u"))
+
+		if err := os.MkdirAll(filepath.Dir(dropInPath), 0755); err != nil {
+			util.HandleError(err, "Failed to create sshd_config.d directory")
+		}
+
+		stagedPath := dropInPath + ".new"
+		if err := writeToFile(stagedPath, strings.Join(dropInLines, "\n")+"\n", 0644); err != nil {
+			util.HandleError(err, "Failed to stage sshd_config drop-in")
+		}
+
+		if err := validateSshdConfigFile(stagedPath); err != nil {
+			os.Remove(stagedPath)
+			util.HandleError(err, "sshd_config drop-in failed validation")
+		}
+
+		if _, err := os.Stat(dropInPath); err == nil {
+			backupPath := fmt.Sprintf("%s.bak.%d", dropInPath, time.Now().Unix())
+			if err := os.Rename(dropInPath, backupPath); err != nil {
+				os.Remove(stagedPath)
+				util.HandleError(err, "Failed to back up existing sshd_config drop-in")
+			}
+			fmt.Println("🗄  Backed up previous drop-in to:", backupPath)
+		}
+
+		if err := os.Rename(stagedPath, dropInPath); err != nil {
+			util.HandleError(err, "Failed to activate sshd_config drop-in")
+		}
+		fmt.Println("📄 Wrote sshd_config drop-in to:", dropInPath)
+
+		if reloadSshdAfterConfigure {
+			reloadSshd()
+		}
+	}
+
+	if output != "" && output != "file" {
+		sink, err := newCredentialSink(output, fileCredentialSink{})
+		if err != nil {
+			util.HandleError(err, "Invalid --output flag")
+		}
+
+		loginUsers := make([]string, len(host.LoginMappings))
+		for i, m := range host.LoginMappings {
+			loginUsers[i] = m.LoginUser
+		}
+
+		if len(issuedHostCerts) == 0 {
+			if err := sink.Emit(CredentialMaterial{CaPublicKey: userCaPublicKey, Principals: loginUsers}); err != nil {
+				util.HandleError(err, "Failed to emit SSH host credentials")
+			}
+		} else {
+			for _, pair := range hostKeyPairs {
+				cert, ok := issuedHostCerts[pair.KeyType]
+				if !ok {
+					continue
+				}
+				material := CredentialMaterial{CaPublicKey: userCaPublicKey, Certificate: cert, Principals: loginUsers}
+				if validAfter, validBefore, err := parseCertValidity(cert); err == nil {
+					material.ValidAfter = validAfter
+					material.ValidBefore = validBefore
+				}
+				if err := sink.Emit(material); err != nil {
+					util.HandleError(err, "Failed to emit SSH host credentials")
+				}
+			}
+		}
+	}
+}
+
+const (
+	infisicalSshdConfigBeginMarker = "# BEGIN INFISICAL MANAGED BLOCK"
+	infisicalSshdConfigEndMarker   = "# END INFISICAL MANAGED BLOCK"
+)
+
+// buildInfisicalSshdManagedBlock renders the TrustedUserCAKeys/HostKey/
+// HostCertificate directives add-host manages, wrapped in the BEGIN/END
+// markers that make the block idempotently findable and replaceable.
+func buildInfisicalSshdManagedBlock(userCaPath string, hostKeyPairs []hostKeyCertPair) string {
+	lines := []string{infisicalSshdConfigBeginMarker, "TrustedUserCAKeys " + userCaPath}
+	for _, pair := range hostKeyPairs {
+		lines = append(lines, "HostKey "+pair.PrivateKeyPath, "HostCertificate "+pair.CertPath)
+	}
+	lines = append(lines, infisicalSshdConfigEndMarker)
+	return strings.Join(lines, "\n")
+}
+
+// replaceManagedSshdConfigBlock removes any existing Infisical managed block
+// from content and puts block in its place (appending it if none existed
+// yet), so re-running add-host is idempotent instead of accumulating stale
+// directives or erroring on lines it already wrote.
+func replaceManagedSshdConfigBlock(content, block string) (string, error) {
+	lines := strings.Split(content, "\n")
+	beginIdx, endIdx := -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case infisicalSshdConfigBeginMarker:
+			if beginIdx != -1 {
+				return "", fmt.Errorf("sshd_config contains multiple %s markers", infisicalSshdConfigBeginMarker)
+			}
+			beginIdx = i
+		case infisicalSshdConfigEndMarker:
+			endIdx = i
+		}
+	}
+
+	if beginIdx == -1 && endIdx == -1 {
+		return strings.TrimRight(content, "\n") + "\n\n" + block + "\n", nil
+	}
+	if beginIdx == -1 || endIdx == -1 || endIdx < beginIdx {
+		return "", fmt.Errorf("sshd_config has a malformed Infisical managed block (unmatched BEGIN/END markers)")
+	}
+
+	newLines := append([]string{}, lines[:beginIdx]...)
+	newLines = append(newLines, strings.Split(block, "\n")...)
+	newLines = append(newLines, lines[endIdx+1:]...)
+	return strings.Join(newLines, "\n"), nil
+}
+
+// stripManagedSshdConfigBlock removes the Infisical managed block from
+// content, if present, reporting whether anything was removed.
+func stripManagedSshdConfigBlock(content string) (string, bool, error) {
+	lines := strings.Split(content, "\n")
+	beginIdx, endIdx := -1, -1
+	for i, line := range lines {
+		switch strings.TrimSpace(line) {
+		case infisicalSshdConfigBeginMarker:
+			beginIdx = i
+		case infisicalSshdConfigEndMarker:
+			endIdx = i
+		}
+	}
+
+	if beginIdx == -1 && endIdx == -1 {
+		return content, false, nil
+	}
+	if beginIdx == -1 || endIdx == -1 || endIdx < beginIdx {
+		return "", false, fmt.Errorf("sshd_config has a malformed Infisical managed block (unmatched BEGIN/END markers)")
+	}
+
+	newLines := append([]string{}, lines[:beginIdx]...)
+	newLines = append(newLines, lines[endIdx+1:]...)
+	return strings.Join(newLines, "\n"), true, nil
+}
+
+// writeValidatedSshdConfig stages newContent next to path, validates it with
+// `sshd -t -f`, keeps a .bak of whatever was previously at path, and
+// atomically renames the staged file into place so a failed validation or
+// write never leaves sshd without a usable config.
+func writeValidatedSshdConfig(path, newContent string) error {
+	stagedPath := path + ".new"
+	if err := writeToFile(stagedPath, newContent, 0644); err != nil {
+		return fmt.Errorf("failed to stage %s: %w", path, err)
+	}
+
+	if err := validateSshdConfigFile(stagedPath); err != nil {
+		os.Remove(stagedPath)
+		return fmt.Errorf("%s failed sshd -t validation: %w", path, err)
+	}
+
+	if existing, err := os.ReadFile(path); err == nil {
+		if err := writeToFile(path+".bak", string(existing), 0644); err != nil {
+			os.Remove(stagedPath)
+			return fmt.Errorf("failed to back up %s: %w", path, err)
+		}
+	}
+
+	if err := os.Rename(stagedPath, path); err != nil {
+		return fmt.Errorf("failed to activate %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// validateSshdConfigFile runs `sshd -t -f <path>` to confirm a candidate
+// sshd_config (or drop-in) is syntactically and semantically valid before
+// it's allowed to replace whatever sshd is currently using.
+func validateSshdConfigFile(path string) error {
+	out, err := exec.Command("sshd", "-t", "-f", path).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%s: %w", strings.TrimSpace(string(out)), err)
+	}
+	return nil
+}
+
+// reloadSshd asks the system service manager to reload sshd so a newly
+// activated drop-in takes effect without dropping existing connections.
+func reloadSshd() {
+	out, err := exec.Command("systemctl", "reload", "sshd").CombinedOutput()
+	if err != nil {
+		fmt.Printf("⚠️  Failed to reload sshd: %s: %v\n", strings.TrimSpace(string(out)), err)
+		return
+	}
+	fmt.Println("🔄 Reloaded sshd")
+}
+
+// writeAuthorizedPrincipalsFiles materializes one authorized_principals file
+// per login user under dir, named to match sshd's AuthorizedPrincipalsFile
+// %u token, so a cert's principal is authorized to log in as that same user.
+func writeAuthorizedPrincipalsFiles(dir string, loginUsers []string) error {
+	for _, loginUser := range loginUsers {
+		path := filepath.Join(dir, loginUser)
+		if err := writeToFile(path, loginUser+"\n", 0644); err != nil {
+			return fmt.Errorf("failed to write authorized_principals file for %s: %w", loginUser, err)
+		}
+	}
+	return nil
+}
+
+// sshRollbackHost restores the most recently backed-up sshd_config drop-in
+// written by `infisical ssh add-host --configureSshdDropin`, re-validating it
+// with sshd -t before it's allowed to take effect.
+func sshRollbackHost(cmd *cobra.Command, args []string) {
+	dropInPath, err := cmd.Flags().GetString("dropInPath")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --dropInPath flag")
+	}
+
+	reload, err := cmd.Flags().GetBool("reload")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --reload flag")
+	}
+
+	dir := filepath.Dir(dropInPath)
+	base := filepath.Base(dropInPath)
+	prefix := base + ".bak."
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		util.HandleError(err, "Failed to read sshd_config.d directory")
+	}
+
+	var backups []string
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), prefix) {
+			backups = append(backups, entry.Name())
+		}
+	}
+	if len(backups) == 0 {
+		util.PrintErrorMessageAndExit("No backups found for " + dropInPath)
+	}
+	sort.Strings(backups)
+	latest := backups[len(backups)-1]
+
+	content, err := os.ReadFile(filepath.Join(dir, latest))
+	if err != nil {
+		util.HandleError(err, "Failed to read backup file")
+	}
+
+	stagedPath := dropInPath + ".rollback"
+	if err := writeToFile(stagedPath, string(content), 0644); err != nil {
+		util.HandleError(err, "Failed to stage rollback")
+	}
+
+	if err := validateSshdConfigFile(stagedPath); err != nil {
+		os.Remove(stagedPath)
+		util.HandleError(err, "Backup failed sshd -t validation")
+	}
+
+	if err := os.Rename(stagedPath, dropInPath); err != nil {
+		util.HandleError(err, "Failed to activate rollback")
+	}
+
+	fmt.Println("⏪ Restored", dropInPath, "from backup", latest)
+
+	if reload {
+		reloadSshd()
+	}
+}
+
+// sshRemoveHost reverses the local sshd_config changes made by
+// `infisical ssh add-host --configureSshd`, stripping the Infisical managed
+// block and validating the result with sshd -t before it takes effect.
+func sshRemoveHost(cmd *cobra.Command, args []string) {
+	unconfigureSshd, err := cmd.Flags().GetBool("unconfigureSshd")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --unconfigureSshd flag")
+	}
+	if !unconfigureSshd {
+		util.PrintErrorMessageAndExit("You must pass --unconfigureSshd")
+	}
+
+	sshdConfig := "/etc/ssh/sshd_config"
+	existing, err := os.ReadFile(sshdConfig)
+	if err != nil {
+		util.HandleError(err, "Failed to read sshd_config")
+	}
+
+	updated, changed, err := stripManagedSshdConfigBlock(string(existing))
+	if err != nil {
+		util.HandleError(err, "Failed to parse sshd_config")
+	}
+	if !changed {
+		fmt.Println("No Infisical managed block found in sshd_config; nothing to do")
+		return
+	}
+
+	if err := writeValidatedSshdConfig(sshdConfig, updated); err != nil {
+		util.HandleError(err, "Failed to update sshd_config")
+	}
+
+	fmt.Println("🗑️  Removed Infisical managed block from sshd_config")
+}
+
+// sighupSshd sends SIGHUP to the sshd process identified by pidFile, which
+// tells it to gracefully reload its host keys/certificates without dropping
+// existing connections.
+func sighupSshd(pidFile string) error {
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		return fmt.Errorf("failed to read sshd pid file: %w", err)
+	}
+
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return fmt.Errorf("invalid sshd pid in %s: %w", pidFile, err)
+	}
+
+	return syscall.Kill(pid, syscall.SIGHUP)
+}
+
+// sshRenewHost runs as a long-lived process (or once, with --once) that keeps
+// a registered host's certificate fresh, renewing it once less than
+// --renewBefore of its TTL remains and signalling sshd to pick up the change.
+func sshRenewHost(cmd *cobra.Command, args []string) {
+	token, err := util.GetInfisicalToken(cmd)
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
+	}
+
+	var infisicalToken string
+	if token != nil && (token.Type == util.SERVICE_TOKEN_IDENTIFIER || token.Type == util.UNIVERSAL_AUTH_TOKEN_IDENTIFIER) {
+		infisicalToken = token.Token
+	} else {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
+
+		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails(true)
+		if err != nil {
+			util.HandleError(err, "Unable to authenticate")
+		}
+		if loggedInUserDetails.LoginExpired {
+			util.PrintErrorMessageAndExit("Your login session has expired, please run [infisical login] and try again")
+		}
+		infisicalToken = loggedInUserDetails.UserCredentials.JTWToken
+	}
+
+	hostId, err := cmd.Flags().GetString("hostId")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --hostId flag")
+	}
+	if hostId == "" {
+		util.PrintErrorMessageAndExit("You must provide --hostId")
+	}
+
+	keyType, err := cmd.Flags().GetString("keyType")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --keyType flag")
+	}
+
+	interval, err := cmd.Flags().GetDuration("interval")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --interval flag")
+	}
+
+	renewBefore, err := cmd.Flags().GetFloat64("renewBefore")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --renewBefore flag")
+	}
+
+	once, err := cmd.Flags().GetBool("once")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --once flag")
+	}
+
+	sshdPidFile, err := cmd.Flags().GetString("sshdPidFile")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --sshdPidFile flag")
+	}
+
+	keyTypes := []string{"ed25519", "ecdsa", "rsa"}
+	if keyType != "" {
+		keyTypes = []string{keyType}
+	}
+
+	var hostPubKeyPath, hostCertPath string
+	for _, kt := range keyTypes {
+		pub := fmt.Sprintf("/etc/ssh/ssh_host_%s_key.pub", kt)
+		if _, err := os.Stat(pub); err == nil {
+			hostPubKeyPath = pub
+			hostCertPath = fmt.Sprintf("/etc/ssh/ssh_host_%s_key-cert.pub", kt)
+			break
+		}
+	}
+	if hostPubKeyPath == "" {
+		util.PrintErrorMessageAndExit("No supported SSH host public key found at /etc/ssh")
+	}
+
+	customHeaders, err := util.GetInfisicalCustomHeadersMap()
+	if err != nil {
+		util.HandleError(err, "Unable to get custom headers")
 	}
-	fmt.Println("✔ SSH credentials successfully added to agent")
 
-	// Connect to host using system ssh and agent
-	target := fmt.Sprintf("%s@%s", selectedLoginUser, selectedHost.Hostname)
-	fmt.Printf("Connecting to %s...\n", target)
+	client := infisicalSdk.NewInfisicalClient(context.Background(), infisicalSdk.Config{
+		SiteUrl:          config.INFISICAL_URL,
+		UserAgent:        api.USER_AGENT,
+		AutoTokenRefresh: false,
+		CustomHeaders:    customHeaders,
+	})
+	client.Auth().SetAccessToken(infisicalToken)
 
-	sshCmd := exec.Command("ssh", target)
-	sshCmd.Stdin = os.Stdin
-	sshCmd.Stdout = os.Stdout
-	sshCmd.Stderr = os.Stderr
+	renewIfNeeded := func() error {
+		needsRenewal := true
+		if certBytes, err := os.ReadFile(hostCertPath); err == nil {
+			if publicKey, _, _, _, err := ssh.ParseAuthorizedKey(certBytes); err == nil {
+				if cert, ok := publicKey.(*ssh.Certificate); ok {
+					if cert.ValidBefore == ssh.CertTimeInfinity {
+						needsRenewal = false
+					} else {
+						validAfter := time.Unix(int64(cert.ValidAfter), 0)
+						validBefore := time.Unix(int64(cert.ValidBefore), 0)
+						ttl := validBefore.Sub(validAfter)
+						needsRenewal = time.Until(validBefore) < time.Duration(float64(ttl)*renewBefore)
+					}
+				}
+			}
+		}
 
-	err = sshCmd.Run()
-	if err != nil {
-		util.HandleError(err, "SSH connection failed")
-	}	
+		if !needsRenewal {
+			return nil
+		}
+
+		pubKeyBytes, err := os.ReadFile(hostPubKeyPath)
+		if err != nil {
+			return fmt.Errorf("failed to read host public key: %w", err)
+		}
+
+		res, err := client.Ssh().IssueSshHostHostCert(hostId, infisicalSdk.IssueSshHostHostCertOptions{
+			PublicKey: string(pubKeyBytes),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to issue host certificate: %w", err)
+		}
+
+		if err := writeToFile(hostCertPath, res.SignedKey, 0644); err != nil {
+			return fmt.Errorf("failed to write host certificate: %w", err)
+		}
+
+		fmt.Println("✔ Host certificate renewed:", hostCertPath)
+
+		if err := sighupSshd(sshdPidFile); err != nil {
+			fmt.Printf("⚠ Renewed certificate but failed to signal sshd: %v\n", err)
+		}
+
+		return nil
+	}
+
+	if once {
+		if err := renewIfNeeded(); err != nil {
+			util.HandleError(err, "Failed to renew host certificate")
+		}
+		return
+	}
+
+	for {
+		if err := renewIfNeeded(); err != nil {
+			fmt.Printf("⚠ Failed to renew host certificate: %v\n", err)
+		}
+		time.Sleep(interval)
+	}
 }
 
-func sshAddHost(cmd *cobra.Command, args []string) {
+// infisicalCaMarker returns the trailing comment that tags a @cert-authority
+// line as managed by Infisical for a given project or host ID, so it can be
+// found and replaced/removed idempotently on subsequent runs.
+func infisicalCaMarker(id string) string {
+	return fmt.Sprintf("# infisical-ca:%s", id)
+}
 
+func sshTrust(cmd *cobra.Command, args []string) {
 	token, err := util.GetInfisicalToken(cmd)
 	if err != nil {
-		util.HandleError(err, "Unable to parse token")
+		util.HandleError(err, "Unable to parse flag")
 	}
 
 	var infisicalToken string
@@ -743,114 +2871,242 @@ func sshAddHost(cmd *cobra.Command, args []string) {
 			util.HandleError(err, "Unable to authenticate")
 		}
 		if loggedInUserDetails.LoginExpired {
-			util.PrintErrorMessageAndExit("Your login session has expired, please run [infisical login]")
+			util.PrintErrorMessageAndExit("Your login session has expired, please run [infisical login] and try again")
 		}
 		infisicalToken = loggedInUserDetails.UserCredentials.JTWToken
 	}
 
-	projectId, err := cmd.Flags().GetString("projectId")
+	hostId, err := cmd.Flags().GetString("hostId")
 	if err != nil {
-		util.HandleError(err, "Unable to parse --projectId flag")
+		util.HandleError(err, "Unable to parse --hostId flag")
 	}
-	if projectId == "" {
-		util.PrintErrorMessageAndExit("You must provide --projectId")
+	if hostId == "" {
+		util.PrintErrorMessageAndExit("You must provide --hostId")
 	}
 
-	hostname, err := cmd.Flags().GetString("hostname")
+	knownHostsFile, err := cmd.Flags().GetString("knownHostsFile")
 	if err != nil {
-		util.HandleError(err, "Unable to parse --hostname flag")
+		util.HandleError(err, "Unable to parse --knownHostsFile flag")
 	}
-	if hostname == "" {
-		util.PrintErrorMessageAndExit("You must provide --hostname")
+	if strings.HasPrefix(knownHostsFile, "~") {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			util.HandleError(err, "Failed to resolve home directory")
+		}
+		knownHostsFile = strings.Replace(knownHostsFile, "~", homeDir, 1)
 	}
 
-	writeUserCaToFile, err := cmd.Flags().GetBool("writeUserCaToFile")
+	remove, err := cmd.Flags().GetBool("remove")
 	if err != nil {
-		util.HandleError(err, "Unable to parse --writeUserCaToFile flag")
+		util.HandleError(err, "Unable to parse --remove flag")
 	}
 
-	userCaOutFilePath, err := cmd.Flags().GetString("userCaOutFilePath")
-	if err != nil {
-		util.HandleError(err, "Unable to parse --userCaOutFilePath flag")
+	marker := infisicalCaMarker(hostId)
+
+	existing, err := os.ReadFile(knownHostsFile)
+	if err != nil && !os.IsNotExist(err) {
+		util.HandleError(err, "Failed to read known_hosts file")
 	}
 
-	writeHostCertToFile, err := cmd.Flags().GetBool("writeHostCertToFile")
+	var lines []string
+	if len(existing) > 0 {
+		lines = strings.Split(strings.TrimRight(string(existing), "\n"), "\n")
+	}
+
+	if remove {
+		kept := lines[:0]
+		for _, line := range lines {
+			if !strings.HasSuffix(strings.TrimSpace(line), marker) {
+				kept = append(kept, line)
+			}
+		}
+		if err := writeToFile(knownHostsFile, strings.Join(kept, "\n")+"\n", 0644); err != nil {
+			util.HandleError(err, "Failed to update known_hosts file")
+		}
+		fmt.Println("🗑 Removed Infisical Host CA trust anchor for host:", hostId)
+		return
+	}
+
+	principalsStr, err := cmd.Flags().GetString("principals")
 	if err != nil {
-		util.HandleError(err, "Unable to parse --writeHostCertToFile flag")
+		util.HandleError(err, "Unable to parse --principals flag")
+	}
+	if principalsStr == "" {
+		principalsStr = "*"
 	}
 
-	configureSshd, err := cmd.Flags().GetBool("configureSshd")
+	customHeaders, err := util.GetInfisicalCustomHeadersMap()
 	if err != nil {
-		util.HandleError(err, "Unable to parse --configureSshd flag")
+		util.HandleError(err, "Unable to get custom headers")
 	}
 
-	forceOverwrite, err := cmd.Flags().GetBool("force")
+	client := infisicalSdk.NewInfisicalClient(context.Background(), infisicalSdk.Config{
+		SiteUrl:          config.INFISICAL_URL,
+		UserAgent:        api.USER_AGENT,
+		AutoTokenRefresh: false,
+		CustomHeaders:    customHeaders,
+	})
+	client.Auth().SetAccessToken(infisicalToken)
+
+	caPublicKey, err := client.Ssh().GetSshHostHostCaPublicKey(hostId)
 	if err != nil {
-		util.HandleError(err, "Unable to parse --force flag")
+		util.HandleError(err, "Failed to fetch the host's Host CA public key")
 	}
 
-	if configureSshd && (!writeUserCaToFile || !writeHostCertToFile) {
-		util.PrintErrorMessageAndExit("--configureSshd requires both --writeUserCaToFile and --writeHostCertToFile to also be set")
+	caLine := fmt.Sprintf("@cert-authority %s %s %s", principalsStr, strings.TrimSpace(caPublicKey), marker)
+
+	replaced := false
+	for i, line := range lines {
+		if strings.HasSuffix(strings.TrimSpace(line), marker) {
+			lines[i] = caLine
+			replaced = true
+			break
+		}
 	}
-	
-	// Pre-check for file overwrites before proceeding
-	if writeUserCaToFile {
-		if strings.HasPrefix(userCaOutFilePath, "~") {
-			homeDir, err := os.UserHomeDir()
+	if !replaced {
+		lines = append(lines, caLine)
+	}
+
+	if err := writeToFile(knownHostsFile, strings.Join(lines, "\n")+"\n", 0644); err != nil {
+		util.HandleError(err, "Failed to update known_hosts file")
+	}
+
+	if replaced {
+		fmt.Println("🔄 Updated Infisical Host CA trust anchor in:", knownHostsFile)
+	} else {
+		fmt.Println("✅ Installed Infisical Host CA trust anchor in:", knownHostsFile)
+	}
+}
+
+const (
+	sshAgentMinBackoff    = 2 * time.Second
+	sshAgentMaxBackoff    = 2 * time.Minute
+	sshAgentRenewFraction = 2.0 / 3.0 // renew once this fraction of the cert's lifetime has elapsed
+)
+
+// sshAgentRenewalDeadline returns the point in time at which cert should be
+// renewed, computed as sshAgentRenewFraction of the way through its validity
+// window.
+func sshAgentRenewalDeadline(cert *ssh.Certificate) time.Time {
+	validFrom := time.Unix(int64(cert.ValidAfter), 0)
+	validUntil := time.Unix(int64(cert.ValidBefore), 0)
+	if cert.ValidBefore == ssh.CertTimeInfinity {
+		validUntil = time.Now().Add(365 * 24 * time.Hour)
+	}
+
+	lifetime := validUntil.Sub(validFrom)
+	return validFrom.Add(time.Duration(float64(lifetime) * sshAgentRenewFraction))
+}
+
+// startAgentListener serves keyring over a unix socket at socketPath so
+// callers can point SSH_AUTH_SOCK at it, the same way they would point it at
+// a regular ssh-agent.
+func startAgentListener(socketPath string, keyring agent.Agent) error {
+	_ = os.Remove(socketPath)
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", socketPath, err)
+	}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
 			if err != nil {
-				util.HandleError(err, "Unable to resolve ~ in userCaOutFilePath")
+				return
 			}
-			userCaOutFilePath = strings.Replace(userCaOutFilePath, "~", homeDir, 1)
-		}
-		if _, err := os.Stat(userCaOutFilePath); err == nil && !forceOverwrite {
-			util.PrintErrorMessageAndExit("File already exists at " + userCaOutFilePath + ". Use --force to overwrite.")
+			go agent.ServeAgent(keyring, conn)
 		}
+	}()
+
+	return nil
+}
+
+func sshRunAgent(cmd *cobra.Command, args []string) {
+	token, err := util.GetInfisicalToken(cmd)
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
 	}
 
-	keyTypes := []string{"ed25519", "ecdsa", "rsa"}
-	var hostKeyPath, certOutPath, hostPrivateKeyPath string
-	if writeHostCertToFile {
-		for _, keyType := range keyTypes {
-			pub := fmt.Sprintf("/etc/ssh/ssh_host_%s_key.pub", keyType)
-			cert := fmt.Sprintf("/etc/ssh/ssh_host_%s_key-cert.pub", keyType)
-			priv := fmt.Sprintf("/etc/ssh/ssh_host_%s_key", keyType)
+	var infisicalToken string
+	if token != nil && (token.Type == util.SERVICE_TOKEN_IDENTIFIER || token.Type == util.UNIVERSAL_AUTH_TOKEN_IDENTIFIER) {
+		infisicalToken = token.Token
+	} else {
+		util.RequireLogin()
+		util.RequireLocalWorkspaceFile()
 
-			if _, err := os.Stat(pub); err == nil {
-				hostKeyPath = pub
-				certOutPath = cert
-				hostPrivateKeyPath = priv
-				break
-			}
+		loggedInUserDetails, err := util.GetCurrentLoggedInUserDetails(true)
+		if err != nil {
+			util.HandleError(err, "Unable to authenticate")
 		}
-
-		if hostKeyPath == "" {
-			util.PrintErrorMessageAndExit("No supported SSH host public key found at /etc/ssh")
+		if loggedInUserDetails.LoginExpired {
+			util.PrintErrorMessageAndExit("Your login session has expired, please run [infisical login] and try again")
 		}
+		infisicalToken = loggedInUserDetails.UserCredentials.JTWToken
+	}
 
-		if _, err := os.Stat(certOutPath); err == nil && !forceOverwrite {
-			util.PrintErrorMessageAndExit("File already exists at " + certOutPath + ". Use --force to overwrite.")
-		}
+	certificateTemplateId, err := cmd.Flags().GetString("certificateTemplateId")
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
+	}
+	if certificateTemplateId == "" {
+		util.PrintErrorMessageAndExit("You must set the --certificateTemplateId flag")
 	}
 
-	if configureSshd {
-		sshdConfig := "/etc/ssh/sshd_config"
-		existing, err := os.ReadFile(sshdConfig)
-		if err != nil {
-			util.HandleError(err, "Failed to read sshd_config")
+	principalsStr, err := cmd.Flags().GetString("principals")
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
+	}
+	if principalsStr == "" {
+		util.HandleError(fmt.Errorf("no principals provided"), "The 'principals' flag cannot be empty")
+	}
+	principals := strings.Split(principalsStr, ",")
+	for i, principal := range principals {
+		principals[i] = strings.TrimSpace(principal)
+	}
+
+	keyAlgorithm, err := cmd.Flags().GetString("keyAlgorithm")
+	if err != nil {
+		util.HandleError(err, "Unable to parse keyAlgorithm flag")
+	}
+	if !isValidKeyAlgorithm(infisicalSdkUtil.CertKeyAlgorithm(keyAlgorithm)) {
+		util.HandleError(fmt.Errorf("invalid keyAlgorithm: %s", keyAlgorithm),
+			"Valid values: RSA_2048, RSA_4096, EC_prime256v1, EC_secp384r1")
+	}
+
+	ttl, err := cmd.Flags().GetString("ttl")
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
+	}
+
+	keyId, err := cmd.Flags().GetString("keyId")
+	if err != nil {
+		util.HandleError(err, "Unable to parse flag")
+	}
+
+	socketPath, err := cmd.Flags().GetString("socket")
+	if err != nil {
+		util.HandleError(err, "Unable to parse --socket flag")
+	}
+
+	var keyring agent.Agent
+	if socketPath != "" {
+		kr := agent.NewKeyring()
+		if err := startAgentListener(socketPath, kr); err != nil {
+			util.HandleError(err, "Failed to start agent listener")
 		}
-		configLines := []string{
-			"TrustedUserCAKeys " + userCaOutFilePath,
-			"HostKey " + hostPrivateKeyPath,
-			"HostCertificate " + certOutPath,
+		keyring = kr
+		fmt.Printf("🔑 Serving SSH agent on %s — export SSH_AUTH_SOCK=%s\n", socketPath, socketPath)
+	} else {
+		socket := os.Getenv("SSH_AUTH_SOCK")
+		if socket == "" {
+			util.PrintErrorMessageAndExit("SSH_AUTH_SOCK is not set. Either start a real ssh-agent or pass --socket to have this command run its own")
 		}
-		for _, line := range configLines {
-			for _, existingLine := range strings.Split(string(existing), "\n") {
-				trimmed := strings.TrimSpace(existingLine)
-				if trimmed == line && !strings.HasPrefix(trimmed, "#") && !forceOverwrite {
-					util.PrintErrorMessageAndExit("sshd_config already contains: " + line + ". Use --force to overwrite.")
-				}
-			}
+		conn, err := net.Dial("unix", socket)
+		if err != nil {
+			util.HandleError(err, "Failed to connect to SSH agent")
 		}
+		keyring = agent.NewClient(conn)
 	}
 
 	customHeaders, err := util.GetInfisicalCustomHeadersMap()
@@ -858,95 +3114,85 @@ func sshAddHost(cmd *cobra.Command, args []string) {
 		util.HandleError(err, "Unable to get custom headers")
 	}
 
-	client := infisicalSdk.NewInfisicalClient(context.Background(), infisicalSdk.Config{
+	infisicalClient := infisicalSdk.NewInfisicalClient(context.Background(), infisicalSdk.Config{
 		SiteUrl:          config.INFISICAL_URL,
 		UserAgent:        api.USER_AGENT,
 		AutoTokenRefresh: false,
 		CustomHeaders:    customHeaders,
 	})
-	client.Auth().SetAccessToken(infisicalToken)
+	infisicalClient.Auth().SetAccessToken(infisicalToken)
 
-	host, err := client.Ssh().AddSshHost(infisicalSdk.AddSshHostOptions{
-		ProjectID: projectId,
-		Hostname:  hostname,
-	})
-	if err != nil {
-		util.HandleError(err, "Failed to register SSH host")
-	}
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGHUP)
 
-	fmt.Println("✅ Successfully registered host:", host.Hostname)
+	var currentCert *ssh.Certificate
 
-	if writeUserCaToFile {
-		publicKey, err := client.Ssh().GetSshHostUserCaPublicKey(host.ID)
+	issueAndLoad := func() (*ssh.Certificate, error) {
+		creds, err := infisicalClient.Ssh().IssueCredentials(infisicalSdk.IssueSshCredsOptions{
+			CertificateTemplateID: certificateTemplateId,
+			Principals:            principals,
+			KeyAlgorithm:          infisicalSdkUtil.CertKeyAlgorithm(keyAlgorithm),
+			CertType:              infisicalSdkUtil.UserCert,
+			TTL:                   ttl,
+			KeyID:                 keyId,
+		})
 		if err != nil {
-			util.HandleError(err, "Failed to fetch associated User CA public key")
-		}
-
-		if err := writeToFile(userCaOutFilePath, publicKey, 0644); err != nil {
-			util.HandleError(err, "Failed to write User CA public key to file")
+			return nil, fmt.Errorf("failed to issue SSH credentials: %w", err)
 		}
 
-		fmt.Println("📁 Wrote User CA public key to:", userCaOutFilePath)
-	}
-
-	if writeHostCertToFile {
-		pubKeyBytes, err := os.ReadFile(hostKeyPath)
+		_, cert, _, err := parseCertAndKey(creds.PrivateKey, creds.SignedKey)
 		if err != nil {
-			util.HandleError(err, "Failed to read SSH host public key")
+			return nil, err
 		}
-		res, err := client.Ssh().IssueSshHostHostCert(host.ID, infisicalSdk.IssueSshHostHostCertOptions{
-			PublicKey: string(pubKeyBytes),
-		})
-		if err != nil {
-			util.HandleError(err, "Failed to issue SSH host certificate")
+
+		if currentCert != nil {
+			// Best-effort: the old identity is about to be replaced.
+			_ = keyring.Remove(currentCert)
 		}
-		if err := writeToFile(certOutPath, res.SignedKey, 0644); err != nil {
-			util.HandleError(err, "Failed to write SSH host certificate to file")
+
+		if err := addCredentialsToKeyring(keyring, creds.PrivateKey, creds.SignedKey); err != nil {
+			return nil, err
 		}
-		fmt.Println("📁 Wrote host certificate to:", certOutPath)
+
+		return cert, nil
 	}
 
-	if configureSshd {
-		sshdConfig := "/etc/ssh/sshd_config"
-		contentBytes, err := os.ReadFile(sshdConfig)
-		if err != nil {
-			util.HandleError(err, "Failed to read sshd_config")
-		}
-		lines := strings.Split(string(contentBytes), "\n")
+	cert, err := issueAndLoad()
+	if err != nil {
+		util.HandleError(err, "Failed to issue initial SSH credentials")
+	}
+	currentCert = cert
+	fmt.Println("✔ SSH user certificate issued and loaded into the agent")
+
+	backoff := sshAgentMinBackoff
 
-		configMap := map[string]string{
-			"TrustedUserCAKeys": userCaOutFilePath,
-			"HostKey":           hostPrivateKeyPath,
-			"HostCertificate":   certOutPath,
+	for {
+		deadline := sshAgentRenewalDeadline(currentCert)
+		wait := time.Until(deadline)
+		if wait < 0 {
+			wait = 0
 		}
 
-		seenKeys := map[string]bool{}
-		for i, line := range lines {
-			trimmed := strings.TrimSpace(line)
-			for key, value := range configMap {
-				if strings.HasPrefix(trimmed, key+" ") {
-					seenKeys[key] = true
-					if strings.HasPrefix(trimmed, "#") || forceOverwrite {
-						lines[i] = fmt.Sprintf("%s %s", key, value)
-					} else {
-						util.PrintErrorMessageAndExit("sshd_config already contains: " + trimmed + ". Use --force to overwrite.")
-					}
-				}
-			}
+		select {
+		case <-time.After(wait):
+		case <-sigCh:
+			fmt.Println("↺ Received SIGHUP, forcing certificate renewal")
 		}
 
-		// Append missing lines
-		for key, value := range configMap {
-			if !seenKeys[key] {
-				lines = append(lines, fmt.Sprintf("%s %s", key, value))
+		cert, err := issueAndLoad()
+		if err != nil {
+			fmt.Printf("⚠ Failed to renew SSH certificate: %v. Retrying in %s\n", err, backoff)
+			time.Sleep(backoff)
+			backoff *= 2
+			if backoff > sshAgentMaxBackoff {
+				backoff = sshAgentMaxBackoff
 			}
+			continue
 		}
 
-		// Write back to file
-		if err := os.WriteFile(sshdConfig, []byte(strings.Join(lines, "\n")), 0644); err != nil {
-			util.HandleError(err, "Failed to update sshd_config")
-		}
-		fmt.Println("📄 Updated sshd_config entries")
+		backoff = sshAgentMinBackoff
+		currentCert = cert
+		fmt.Println("✔ SSH user certificate renewed")
 	}
 }
 
@@ -954,12 +3200,17 @@ func init() {
 	sshSignKeyCmd.Flags().String("token", "", "Issue SSH certificate using machine identity access token")
 	sshSignKeyCmd.Flags().String("certificateTemplateId", "", "The ID of the SSH certificate template to issue the SSH certificate for")
 	sshSignKeyCmd.Flags().String("publicKey", "", "The public key to sign")
-	sshSignKeyCmd.Flags().String("publicKeyFilePath", "", "The file path to the public key file to sign")
+	sshSignKeyCmd.Flags().String("publicKeyFilePath", "", "The file path to the public key file to sign. May also point at a private key (e.g. ~/.ssh/id_ed25519), in which case the public key is derived from it")
+	sshSignKeyCmd.Flags().String("passphrase", "", "Passphrase to decrypt --publicKeyFilePath if it is an encrypted private key")
+	sshSignKeyCmd.Flags().String("passphrase-env", "", "Name of an environment variable containing the passphrase to decrypt --publicKeyFilePath")
+	sshSignKeyCmd.Flags().String("passphrase-file", "", "Path to a file containing the passphrase to decrypt --publicKeyFilePath")
+	sshSignKeyCmd.Flags().Bool("addToAgent", false, "Whether to add the decrypted private key and signed certificate to the SSH agent. Requires --publicKeyFilePath to point at a private key")
 	sshSignKeyCmd.Flags().String("outFilePath", "", "The path to write the SSH certificate to such as ~/.ssh/id_rsa-cert.pub. If not provided, the credentials will be saved to the directory of the specified public key file path or the current working directory")
 	sshSignKeyCmd.Flags().String("principals", "", "The principals that the certificate should be signed for")
 	sshSignKeyCmd.Flags().String("certType", string(infisicalSdkUtil.UserCert), "The cert type for the created certificate")
 	sshSignKeyCmd.Flags().String("ttl", "", "The ttl for the created certificate")
 	sshSignKeyCmd.Flags().String("keyId", "", "The keyId that the created certificate should have")
+	sshSignKeyCmd.Flags().String("output", "", "Where to additionally emit the signed certificate: file, json, agent, or memory (default: file only, via --outFilePath)")
 	sshCmd.AddCommand(sshSignKeyCmd)
 
 	sshIssueCredentialsCmd.Flags().String("token", "", "Issue SSH credentials using machine identity access token")
@@ -971,21 +3222,94 @@ func init() {
 	sshIssueCredentialsCmd.Flags().String("keyId", "", "The keyId to issue SSH credentials for")
 	sshIssueCredentialsCmd.Flags().String("outFilePath", "", "The path to write the SSH credentials to such as ~/.ssh, ./some_folder, ./some_folder/id_rsa-cert.pub. If not provided, the credentials will be saved to the current working directory")
 	sshIssueCredentialsCmd.Flags().Bool("addToAgent", false, "Whether to add issued SSH credentials to the SSH agent")
+	sshIssueCredentialsCmd.Flags().String("identityFile", "", "Write the private key, signed certificate, and TTL into a single self-contained identity file at this path, suitable for passing between CI jobs or machines via `infisical ssh load-identity`")
+	sshIssueCredentialsCmd.Flags().String("hostname", "", "Hostname hint to embed in --identityFile's metadata, so `ssh connect --identityFile` can infer the target without also passing --hostname")
+	sshIssueCredentialsCmd.Flags().String("output", "", "Where to additionally emit the issued credentials: file, json, agent, or memory (default: file only, via --outFilePath)")
 	sshCmd.AddCommand(sshIssueCredentialsCmd)
 
 	sshConnectCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshConnectCmd.Flags().String("bastionHost", "", "Hostname or IP of a bastion/jump host to traverse before reaching the selected SSH host")
+	sshConnectCmd.Flags().String("bastionUser", "", "User to authenticate as on the bastion host. Defaults to the selected login user")
+	sshConnectCmd.Flags().Int("bastionPort", 22, "SSH port of the bastion host")
+	sshConnectCmd.Flags().String("bastionCertificateTemplateId", "", "Certificate template ID used to issue a short-lived user cert for the bastion host (required when --bastionHost is set)")
+	sshConnectCmd.Flags().String("identityFile", "", "Seed the connection from a self-contained identity file (from `issue-credentials --identityFile`) instead of logging in and issuing credentials interactively")
+	sshConnectCmd.Flags().String("hostname", "", "Target hostname to connect to. Required with --identityFile unless the identity file embeds exactly one hostname hint")
+	sshConnectCmd.Flags().String("loginUser", "", "Login user to connect as. Required with --identityFile unless it can be derived from the certificate's principals")
+	sshConnectCmd.Flags().Bool("insecure", false, "Proceed without host certificate validation if the Host CA cannot be fetched, instead of failing closed")
 	sshCmd.AddCommand(sshConnectCmd)
 
+	sshLoadIdentityCmd.Flags().String("file", "", "Path to the identity file to load (required)")
+	sshCmd.AddCommand(sshLoadIdentityCmd)
+
+	sshSftpCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshSftpCmd.Flags().String("get", "", "Remote path to download")
+	sshSftpCmd.Flags().String("out", "", "Local path to write the downloaded file to (required with --get)")
+	sshSftpCmd.Flags().String("put", "", "Local path to upload")
+	sshSftpCmd.Flags().String("remote", "", "Remote destination path (required with --put)")
+	sshSftpCmd.Flags().Bool("insecure", false, "Proceed without host certificate validation if the Host CA cannot be fetched, instead of failing closed")
+	sshCmd.AddCommand(sshSftpCmd)
+
+	sshScpCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshScpCmd.Flags().Bool("insecure", false, "Proceed without host certificate validation if the Host CA cannot be fetched, instead of failing closed")
+	sshCmd.AddCommand(sshScpCmd)
+
+	sshForwardCmd.Flags().StringP("local", "L", "", "Forward a local port through the SSH host: [bind_address:]port:host:hostport")
+	sshForwardCmd.Flags().StringP("remote", "R", "", "Forward a port on the SSH host back to this machine: [bind_address:]port:host:hostport")
+	sshForwardCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshForwardCmd.Flags().Bool("insecure", false, "Proceed without host certificate validation if the Host CA cannot be fetched, instead of failing closed")
+	sshCmd.AddCommand(sshForwardCmd)
+
 	sshAddHostCmd.Flags().String("token", "", "Use a machine identity access token")
 	sshAddHostCmd.Flags().String("projectId", "", "Project ID the host belongs to (required)")
 	sshAddHostCmd.Flags().String("hostname", "", "Hostname of the SSH host (required)")
 	sshAddHostCmd.Flags().Bool("writeUserCaToFile", false, "Write User CA public key to /etc/ssh/infisical_user_ca.pub")
 	sshAddHostCmd.Flags().String("userCaOutFilePath", "/etc/ssh/infisical_user_ca.pub", "Custom file path to write the User CA public key")
 	sshAddHostCmd.Flags().Bool("writeHostCertToFile", false, "Write SSH host certificate to /etc/ssh/ssh_host_<type>_key-cert.pub")
+	sshAddHostCmd.Flags().String("keyTypes", "", "Comma-separated host key types to consider, e.g. \"ed25519,rsa\" (default: ed25519,ecdsa,rsa)")
 	sshAddHostCmd.Flags().Bool("configureSshd", false, "Update TrustedUserCAKeys, HostKey, and HostCertificate in the sshd_config file")
+	sshAddHostCmd.Flags().Bool("configureSshdDropin", false, "Write a validated TrustedUserCAKeys/HostKey/HostCertificate/AuthorizedPrincipalsFile drop-in instead of editing sshd_config directly, with automatic backup")
+	sshAddHostCmd.Flags().String("dropInPath", "/etc/ssh/sshd_config.d/50-infisical.conf", "Path to write the sshd_config drop-in used by --configureSshdDropin")
+	sshAddHostCmd.Flags().String("authorizedPrincipalsDir", "/etc/ssh/authorized_principals.d", "Directory to materialize per-login authorized_principals files into when using --configureSshdDropin")
+	sshAddHostCmd.Flags().Bool("reload", false, "Reload sshd via systemctl after activating the drop-in written by --configureSshdDropin")
 	sshAddHostCmd.Flags().Bool("force", false, "Force overwrite of existing certificate files as part of writeUserCaToFile and writeHostCertToFile")
+	sshAddHostCmd.Flags().String("output", "", "Where to additionally emit the User CA public key and any issued host certificates: file, json, or memory (requires --writeUserCaToFile and/or --writeHostCertToFile)")
+	sshAddHostCmd.Flags().Bool("autodiscover", false, "Resolve --hostname from os.Hostname() when it is omitted, and print the reverse-DNS names of this machine's non-loopback interfaces. The extra names are not registered as principals")
+	sshAddHostCmd.Flags().Bool("writeKnownHostsCaLine", false, "Write an @cert-authority line for the project's Host CA to --knownHostsCaFile so clients trust the issued host certificate")
+	sshAddHostCmd.Flags().String("knownHostsCaFile", "/etc/ssh/ssh_known_hosts", "Path to the known_hosts-style file to write the Host CA trust anchor into when using --writeKnownHostsCaLine")
 
 	sshCmd.AddCommand(sshAddHostCmd)
 
+	sshRollbackHostCmd.Flags().String("dropInPath", "/etc/ssh/sshd_config.d/50-infisical.conf", "Path to the sshd_config drop-in to roll back")
+	sshRollbackHostCmd.Flags().Bool("reload", false, "Reload sshd via systemctl after restoring the backup")
+	sshCmd.AddCommand(sshRollbackHostCmd)
+
+	sshRenewHostCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshRenewHostCmd.Flags().String("hostId", "", "ID of the registered SSH host whose certificate should be renewed (required)")
+	sshRenewHostCmd.Flags().String("keyType", "", "Host key type to renew (ed25519, ecdsa, or rsa). Autodetected from /etc/ssh if omitted")
+	sshRenewHostCmd.Flags().Duration("interval", time.Hour, "How often to check whether the host certificate needs renewal")
+	sshRenewHostCmd.Flags().Float64("renewBefore", 1.0/3.0, "Renew once less than this fraction of the certificate's TTL remains")
+	sshRenewHostCmd.Flags().Bool("once", false, "Check and renew if needed, then exit, instead of running continuously")
+	sshRenewHostCmd.Flags().String("sshdPidFile", "/var/run/sshd.pid", "Path to sshd's PID file, used to send SIGHUP after renewal")
+	sshCmd.AddCommand(sshRenewHostCmd)
+
+	sshRemoveHostCmd.Flags().Bool("unconfigureSshd", false, "Strip the Infisical managed block (BEGIN/END INFISICAL MANAGED BLOCK) from /etc/ssh/sshd_config")
+	sshCmd.AddCommand(sshRemoveHostCmd)
+
+	sshTrustCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshTrustCmd.Flags().String("hostId", "", "ID of the registered SSH host to fetch the Host CA for (required)")
+	sshTrustCmd.Flags().String("knownHostsFile", "~/.ssh/known_hosts", "The known_hosts file to install the Host CA trust anchor into")
+	sshTrustCmd.Flags().String("principals", "*", "Comma-separated host patterns the Host CA should be trusted for")
+	sshTrustCmd.Flags().Bool("remove", false, "Remove the Infisical Host CA trust anchor for --hostId instead of installing it")
+	sshCmd.AddCommand(sshTrustCmd)
+
+	sshAgentCmd.Flags().String("token", "", "Use a machine identity access token")
+	sshAgentCmd.Flags().String("certificateTemplateId", "", "The ID of the SSH certificate template to issue SSH credentials for")
+	sshAgentCmd.Flags().String("principals", "", "The principals to issue SSH credentials for")
+	sshAgentCmd.Flags().String("keyAlgorithm", string(infisicalSdkUtil.RSA2048), "The key algorithm to issue SSH credentials for")
+	sshAgentCmd.Flags().String("ttl", "", "The ttl to issue SSH credentials for")
+	sshAgentCmd.Flags().String("keyId", "", "The keyId to issue SSH credentials for")
+	sshAgentCmd.Flags().String("socket", "", "Path to expose a local agent listener on, suitable for pointing SSH_AUTH_SOCK at. If not set, the certificate is kept renewed in the agent at $SSH_AUTH_SOCK instead")
+	sshCmd.AddCommand(sshAgentCmd)
+
 	rootCmd.AddCommand(sshCmd)
 }